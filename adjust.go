@@ -0,0 +1,44 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrAdjusted is the category sentinel of AdjustedError.
+var ErrAdjusted = errors.New("sanity:adjusted")
+
+// AdjustedError records a field that a sanitizer corrected in place: Old is
+// the value it found, New the value it stored. Sanitizers return these as an
+// aggregate of soft findings, typically logged rather than rejected.
+type AdjustedError struct {
+	Field string
+	Old   any
+	New   any
+}
+
+func (e AdjustedError) Unwrap() error     { return ErrAdjusted }
+func (e AdjustedError) FieldName() string { return e.Field }
+func (e AdjustedError) Code() string      { return CodeAdjusted }
+func (e AdjustedError) Error() string     { return e.FieldName() + ": " + e.message(redacting()) }
+func (e AdjustedError) gotValue() any     { return e.Old }
+func (e AdjustedError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e AdjustedError) message(redact bool) string {
+	if redact {
+		return "was adjusted"
+	}
+	return fmt.Sprintf("adjusted from %v to %v", e.Old, e.New)
+}
+
+// adjust stores v in *p if it differs, recording the change in g.
+func adjust[T comparable](g *Guard, name string, p *T, v T) {
+	if *p == v {
+		return
+	}
+	g.Add(AdjustedError{Field: name, Old: *p, New: v})
+	*p = v
+}
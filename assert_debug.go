@@ -0,0 +1,22 @@
+//go:build sanitydebug
+
+package sanity
+
+import "fmt"
+
+// DebugAssertions reports whether Assert/AssertNoErr are active in this build.
+const DebugAssertions = true
+
+// Assert panics with the formatted msg when cond is false.
+func Assert(cond bool, msg string, args ...any) {
+	if !cond {
+		panic("sanity: assertion failed: " + fmt.Sprintf(msg, args...))
+	}
+}
+
+// AssertNoErr panics when err is non-nil.
+func AssertNoErr(err error) {
+	if err != nil {
+		panic(fmt.Errorf("sanity: assertion failed: %w", err))
+	}
+}
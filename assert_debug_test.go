@@ -0,0 +1,58 @@
+//go:build sanitydebug
+
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestAssert_Debug(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "DebugAssertions enabled",
+			function: func() interface{} {
+				return sanity.DebugAssertions
+			},
+			expected: true,
+		},
+		{
+			name: "Assert true does not panic",
+			function: func() interface{} {
+				return recovered(func() { sanity.Assert(true, "never") }) == nil
+			},
+			expected: true,
+		},
+		{
+			name: "Assert false panics with formatted message",
+			function: func() interface{} {
+				return recovered(func() { sanity.Assert(1 > 2, "n=%d", 7) })
+			},
+			expected: "sanity: assertion failed: n=7",
+		},
+		{
+			name: "AssertNoErr panics with wrapped error",
+			function: func() interface{} {
+				r := recovered(func() { sanity.AssertNoErr(sanity.NonEmpty("s", "")) })
+				err, ok := r.(error)
+				return ok && errors.Is(err, sanity.ErrNonEmpty)
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			if got != tc.expected {
+				t.Errorf("Failed %s: expected %v, got %v", tc.name, tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !sanitydebug
+
+package sanity
+
+// DebugAssertions reports whether Assert/AssertNoErr are active in this build.
+const DebugAssertions = false
+
+// Assert is a no-op unless built with -tags=sanitydebug.
+func Assert(cond bool, msg string, args ...any) {}
+
+// AssertNoErr is a no-op unless built with -tags=sanitydebug.
+func AssertNoErr(err error) {}
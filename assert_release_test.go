@@ -0,0 +1,48 @@
+//go:build !sanitydebug
+
+package sanity_test
+
+import (
+	"testing"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestAssert_Release(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "DebugAssertions disabled",
+			function: func() interface{} {
+				return sanity.DebugAssertions
+			},
+			expected: false,
+		},
+		{
+			name: "Assert false is a no-op",
+			function: func() interface{} {
+				return recovered(func() { sanity.Assert(false, "boom") }) == nil
+			},
+			expected: true,
+		},
+		{
+			name: "AssertNoErr is a no-op",
+			function: func() interface{} {
+				return recovered(func() { sanity.AssertNoErr(sanity.NonEmpty("s", "")) }) == nil
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			if got != tc.expected {
+				t.Errorf("Failed %s: expected %v, got %v", tc.name, tc.expected, got)
+			}
+		})
+	}
+}
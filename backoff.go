@@ -0,0 +1,56 @@
+package sanity
+
+import (
+	"math"
+	"time"
+)
+
+// Defaults applied by SanitizeBackoff.
+const (
+	DefaultBackoffInitial    = 100 * time.Millisecond
+	DefaultBackoffMax        = 30 * time.Second
+	DefaultBackoffMultiplier = 2.0
+)
+
+// BackoffConfig is the usual shape of an exponential retry/backoff policy.
+// Jitter is the randomized fraction of each delay, in [0,1].
+type BackoffConfig struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     float64
+}
+
+// SanitizeBackoff corrects cfg in place:
+//   - non-positive Initial and Max get DefaultBackoffInitial/DefaultBackoffMax;
+//   - a zero or NaN Multiplier gets DefaultBackoffMultiplier, and one below 1
+//     (which would shrink delays) is raised to 1;
+//   - Jitter is clamped to [0,1], NaN becoming 0;
+//   - Max is raised to Initial if it is smaller.
+//
+// It returns nil if nothing changed, otherwise the AdjustedErrors describing
+// each correction (an ErrorGroup if there are several). A nil cfg is a no-op.
+func SanitizeBackoff(cfg *BackoffConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	g := NewGuard(WithMaxErrors(0))
+	if cfg.Initial <= 0 {
+		adjust(&g, "initial", &cfg.Initial, DefaultBackoffInitial)
+	}
+	maxDelay := cfg.Max
+	if maxDelay <= 0 {
+		maxDelay = DefaultBackoffMax
+	}
+	adjust(&g, "max", &cfg.Max, max(maxDelay, cfg.Initial)) // one report, from the input value
+	switch m := cfg.Multiplier; {
+	case m == 0 || math.IsNaN(m):
+		adjust(&g, "multiplier", &cfg.Multiplier, DefaultBackoffMultiplier)
+	case m < 1:
+		adjust(&g, "multiplier", &cfg.Multiplier, 1)
+	}
+	j := cfg.Jitter
+	ClampUnit(&j)
+	adjust(&g, "jitter", &cfg.Jitter, j)
+	return g.Err()
+}
@@ -0,0 +1,101 @@
+package sanity_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestSanitizeBackoff(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Zero config gets defaults",
+			function: func() interface{} {
+				var cfg sanity.BackoffConfig
+				err := sanity.SanitizeBackoff(&cfg)
+				return []interface{}{cfg, fieldsOf(err), errors.Is(err, sanity.ErrAdjusted)}
+			},
+			expected: []interface{}{
+				sanity.BackoffConfig{Initial: 100 * time.Millisecond, Max: 30 * time.Second, Multiplier: 2},
+				[]string{"initial", "max", "multiplier"},
+				true,
+			},
+		},
+		{
+			name: "Sane config is untouched",
+			function: func() interface{} {
+				cfg := sanity.BackoffConfig{Initial: time.Second, Max: time.Minute, Multiplier: 1.5, Jitter: 0.1}
+				err := sanity.SanitizeBackoff(&cfg)
+				return []interface{}{cfg, err == nil, sanity.SanitizeBackoff(nil) == nil}
+			},
+			expected: []interface{}{
+				sanity.BackoffConfig{Initial: time.Second, Max: time.Minute, Multiplier: 1.5, Jitter: 0.1},
+				true,
+				true,
+			},
+		},
+		{
+			name: "Shrinking multiplier, bad jitter and Max < Initial corrected",
+			function: func() interface{} {
+				cfg := sanity.BackoffConfig{Initial: time.Minute, Max: time.Second, Multiplier: 0.5, Jitter: 3}
+				err := sanity.SanitizeBackoff(&cfg)
+				return []interface{}{cfg, fieldsOf(err)}
+			},
+			expected: []interface{}{
+				sanity.BackoffConfig{Initial: time.Minute, Max: time.Minute, Multiplier: 1, Jitter: 1},
+				[]string{"max", "multiplier", "jitter"},
+			},
+		},
+		{
+			name: "Unset Max below a large Initial is reported once, from its input value",
+			function: func() interface{} {
+				cfg := sanity.BackoffConfig{Initial: time.Minute, Multiplier: 2}
+				err := sanity.SanitizeBackoff(&cfg)
+				var ae sanity.AdjustedError
+				errors.As(err, &ae)
+				return []interface{}{cfg.Max, fieldsOf(err), ae.Old, ae.New}
+			},
+			expected: []interface{}{time.Minute, []string{"max"}, time.Duration(0), time.Minute},
+		},
+		{
+			name: "NaN multiplier and jitter",
+			function: func() interface{} {
+				cfg := sanity.BackoffConfig{Initial: time.Second, Max: time.Second, Multiplier: math.NaN(), Jitter: math.NaN()}
+				sanity.SanitizeBackoff(&cfg)
+				return []float64{cfg.Multiplier, cfg.Jitter}
+			},
+			expected: []float64{2, 0},
+		},
+		{
+			name: "AdjustedError reports old and new values",
+			function: func() interface{} {
+				cfg := sanity.BackoffConfig{Initial: time.Second, Max: 2 * time.Second, Multiplier: -1}
+				err := sanity.SanitizeBackoff(&cfg)
+				var ae sanity.AdjustedError
+				errors.As(err, &ae)
+				verbose := err.Error()
+				return []interface{}{
+					ae.Old, ae.New, sanity.CodeOf(err),
+					verbose == "multiplier: adjusted from -1 to 1" || sanity.RedactBuild,
+					sanity.Redacted(err).Error(),
+				}
+			},
+			expected: []interface{}{-1.0, 1.0, sanity.CodeAdjusted, true, "multiplier: was adjusted"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package sanity
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// ValidateSlice runs fn for every element of xs with a fresh index-scoped Guard
+// built from opts (default first-error, i.e. one error per index), then returns
+// a single aggregate whose members carry "name[i].field" paths. Per-index clamp
+// sentinels are omitted: the index cap is a deliberate budget, not a finding.
+// With WithDedup among opts, repeats are also collapsed across indexes.
+func ValidateSlice[T any](name string, xs []T, fn func(i int, v T, g *Guard), opts ...GuardOption) error {
+	out := NewGuard(WithMaxErrors(0))
+	if cfg := NewGuard(opts...); cfg.dedup {
+		out.dedup = true
+	}
+	for i := range xs {
+		g := NewGuard(opts...)
+		fn(i, xs[i], &g)
+		err := g.Err()
+		if err == nil {
+			continue
+		}
+		path := Indexed(name, i).String()
+		for _, e := range GroupAsSlice(err, nil) {
+			if _, clamped := e.(ErrorsClampedError); clamped {
+				continue
+			}
+			out.Add(WithPrefix(path, e))
+		}
+	}
+	return out.Err()
+}
+
+// Each runs fn for every element of s and returns an aggregate of the
+// failures (nil if none). FieldErrors are prefixed with the element path
+// ("items[3].port"); other errors become ElementError{Field: "items[3]"}.
+func Each[T any](name string, s []T, fn func(T) error) error {
+	out := NewGuard(WithMaxErrors(0))
+	for i, v := range s {
+		err := fn(v)
+		if err == nil {
+			continue
+		}
+		path := Indexed(name, i).String()
+		for e := range Errors(err) {
+			var fe FieldError
+			if errors.As(e, &fe) {
+				out.Add(WithPrefix(path, e))
+			} else {
+				out.Add(ElementError{Field: path, Index: i, Err: e})
+			}
+		}
+	}
+	return out.Err()
+}
+
+// EachKV runs fn for every entry of m and returns an aggregate of the
+// failures with "name[key]" paths (see WithPrefix), ordered by key so the
+// result does not depend on map iteration order.
+func EachKV[K comparable, V any](name string, m map[K]V, fn func(K, V) error) error {
+	type failure struct {
+		path string
+		err  error
+	}
+	var failed []failure
+	for k, v := range m {
+		if err := fn(k, v); err != nil {
+			failed = append(failed, failure{path: keyPath(name, k), err: err})
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	slices.SortFunc(failed, func(a, b failure) int { return strings.Compare(a.path, b.path) })
+	out := NewGuard(WithMaxErrors(0))
+	for _, f := range failed {
+		for e := range Errors(f.err) {
+			out.Add(WithPrefix(f.path, e))
+		}
+	}
+	return out.Err()
+}
@@ -0,0 +1,144 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+type record struct {
+	Name string
+	Port int
+}
+
+func validateRecord(_ int, r record, g *sanity.Guard) {
+	g.Check(sanity.NonEmpty("name", r.Name))
+	g.Check(sanity.InRangeNum("port", r.Port, 1, 65535))
+}
+
+func TestValidateSlice(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "All valid -> nil",
+			function: func() interface{} {
+				xs := []record{{"a", 1}, {"b", 2}}
+				return sanity.ValidateSlice("items", xs, validateRecord) == nil
+			},
+			expected: true,
+		},
+		{
+			name: "Default per-index cap keeps first error per item",
+			function: func() interface{} {
+				xs := []record{{"a", 1}, {"", 0}, {"c", 0}}
+				var paths []string
+				for _, e := range sanity.GroupAsSlice(sanity.ValidateSlice("items", xs, validateRecord), nil) {
+					paths = append(paths, fieldOf(e))
+				}
+				return paths
+			},
+			expected: []string{"items[1].name", "items[2].port"},
+		},
+		{
+			name: "Per-index cap via options",
+			function: func() interface{} {
+				xs := []record{{"", 0}}
+				err := sanity.ValidateSlice("items", xs, validateRecord, sanity.WithMaxErrors(0))
+				n, _ := sanity.GroupLen(err)
+				return []interface{}{n, errors.Is(err, sanity.ErrNonEmpty), errors.Is(err, sanity.ErrOutOfRange)}
+			},
+			expected: []interface{}{2, true, true},
+		},
+		{
+			name: "Single failure still returns the typed error",
+			function: func() interface{} {
+				xs := []record{{"a", 1}, {"b", 0}}
+				var oe sanity.OutOfRangeError[int]
+				ok := errors.As(sanity.ValidateSlice("items", xs, validateRecord), &oe)
+				return []interface{}{ok, oe.Field}
+			},
+			expected: []interface{}{true, "items[1].port"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestEach(t *testing.T) {
+	checkRecord := func(r record) error {
+		g := sanity.NewGuard(sanity.WithMaxErrors(0))
+		validateRecord(0, r, &g)
+		return g.Err()
+	}
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "All valid -> nil",
+			function: func() interface{} {
+				return sanity.Each("items", []record{{"a", 1}}, checkRecord) == nil
+			},
+			expected: true,
+		},
+		{
+			name: "FieldErrors get element paths",
+			function: func() interface{} {
+				err := sanity.Each("items", []record{{"a", 1}, {"", 0}, {"c", 0}}, checkRecord)
+				return fieldsOf(err)
+			},
+			expected: []string{"items[1].name", "items[1].port", "items[2].port"},
+		},
+		{
+			name: "Plain errors become ElementError",
+			function: func() interface{} {
+				bad := errors.New("unreachable")
+				err := sanity.Each("hosts", []string{"a", "b"}, func(h string) error {
+					if h == "b" {
+						return bad
+					}
+					return nil
+				})
+				var ee sanity.ElementError
+				return []interface{}{errors.As(err, &ee), ee.Field, ee.Index, errors.Is(err, bad), err.Error()}
+			},
+			expected: []interface{}{true, "hosts[1]", 1, true, "hosts[1]: unreachable"},
+		},
+		{
+			name: "EachKV orders by key",
+			function: func() interface{} {
+				m := map[string]int{"b": 0, "a": 0, "c": 5}
+				err := sanity.EachKV("limits", m, func(_ string, v int) error {
+					return sanity.InRangeNum("", v, 1, 10)
+				})
+				return fieldsOf(err)
+			},
+			expected: []string{"limits[a]", "limits[b]"},
+		},
+		{
+			name: "EachKV all valid -> nil",
+			function: func() interface{} {
+				return sanity.EachKV("m", map[int]int{1: 1}, func(int, int) error { return nil }) == nil
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -138,4 +138,38 @@ func BenchmarkGuard(b *testing.B) {
 			sinkInt += count
 		}
 	})
+
+	b.Run("Pooled/OK/Cap=4", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			g := sanity.AcquireGuard(sanity.WithMaxErrors(4))
+			g.Check(sanity.NonEmpty("mode", "auto"))
+			g.Check(sanity.InRangeNum("temp", 12.34, 0.0, 30.0))
+			sinkErr = g.Err()
+			sanity.ReleaseGuard(g)
+		}
+	})
+
+	b.Run("Err/SSO4/Repeated", func(b *testing.B) {
+		g := sanity.NewGuard(sanity.WithMaxErrors(0))
+		g.Add(sanity.NonEmpty("a", ""))
+		g.Add(sanity.NonZero("b", 0))
+		g.Add(sanity.InRangeNum("c", -1, 0, 1))
+		g.Add(sanity.StrLenAtLeast("d", "ab", 3))
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			sinkErr = g.Err()
+		}
+	})
+
+	b.Run("Err/ThreadSafe/Single", func(b *testing.B) {
+		g := sanity.NewGuard(sanity.WithThreadSafe())
+		g.Add(sanity.NonEmpty("a", ""))
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			sinkErr = g.Err()
+		}
+	})
 }
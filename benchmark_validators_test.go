@@ -1,6 +1,7 @@
 package sanity_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -104,4 +105,48 @@ func BenchmarkValidators(b *testing.B) {
 			blackbox(sinkErr)
 		}
 	})
+
+	b.Run("InRangeNum/OK/SprintfName", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			v := okNums[i&7]
+			if err := sanity.InRangeNum(fmt.Sprintf("w%d.i%d", i|1<<20, i&3), v, 0, 10); err != nil {
+				b.Fatal("unexpected")
+			}
+		}
+	})
+
+	b.Run("InRangeNumf/OK", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			v := okNums[i&7]
+			// Indexes >= 256 are boxed on every call; see InRangeNumN/OK/Indexed.
+			if err := sanity.InRangeNumf(v, 0, 10, "w%d.i%d", i|1<<20, i&3); err != nil {
+				b.Fatal("unexpected")
+			}
+		}
+	})
+
+	b.Run("InRangeNumf/Fail", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			v := badNums[i&7]
+			sinkErr = sanity.InRangeNumf(v, 0, 10, "w%d.i%d", i|1<<20, i&3)
+			blackbox(sinkErr)
+		}
+	})
+
+	b.Run("MatchesRegex/OK/Cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := sanity.MatchesRegex("mode", okModes[i&3], `^[a-z]+$`); err != nil {
+				b.Fatal("unexpected")
+			}
+		}
+	})
+
+	b.Run("InRangeNumN/OK/Indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			v := okNums[i&7]
+			if err := sanity.InRangeNumN(sanity.Indexed("items", i|1<<20), v, 0, 10); err != nil {
+				b.Fatal("unexpected")
+			}
+		}
+	})
 }
@@ -0,0 +1,107 @@
+package sanity
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a size in bytes that prints human-readably (String), so
+// OutOfRangeError[ByteSize] from InRangeBytes renders "512MiB" rather than
+// 536870912.
+type ByteSize int64
+
+// Decimal (SI) and binary (IEC) size units.
+const (
+	KB ByteSize = 1000
+	MB          = 1000 * KB
+	GB          = 1000 * MB
+	TB          = 1000 * GB
+	PB          = 1000 * TB
+
+	KiB ByteSize = 1 << 10
+	MiB          = 1024 * KiB
+	GiB          = 1024 * MiB
+	TiB          = 1024 * GiB
+	PiB          = 1024 * TiB
+)
+
+var byteUnits = map[string]ByteSize{
+	"": 1, "b": 1,
+	"k": KB, "kb": KB, "m": MB, "mb": MB, "g": GB, "gb": GB, "t": TB, "tb": TB, "p": PB, "pb": PB,
+	"ki": KiB, "kib": KiB, "mi": MiB, "mib": MiB, "gi": GiB, "gib": GiB, "ti": TiB, "tib": TiB, "pi": PiB, "pib": PiB,
+}
+
+var iecUnits = [...]struct {
+	size ByteSize
+	name string
+}{{PiB, "PiB"}, {TiB, "TiB"}, {GiB, "GiB"}, {MiB, "MiB"}, {KiB, "KiB"}}
+
+// String formats b with the largest binary unit it reaches, with at most two
+// decimals: 536870912 => "512MiB", 1536 => "1.5KiB", 100 => "100B".
+func (b ByteSize) String() string {
+	n := b
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	for _, u := range iecUnits {
+		if n >= u.size {
+			v := math.Round(float64(n)/float64(u.size)*100) / 100
+			return sign + strconv.FormatFloat(v, 'f', -1, 64) + u.name
+		}
+	}
+	return sign + strconv.FormatInt(int64(n), 10) + "B"
+}
+
+// ParseBytes parses sizes such as "512MiB", "1.5GB", "64k" or "100".
+// Units are case-insensitive; K/M/G/T/P are decimal (1000), Ki/Mi/Gi/Ti/Pi
+// binary (1024), with an optional trailing "B". Negative sizes are rejected.
+func ParseBytes(s string) (ByteSize, error) {
+	t := strings.TrimSpace(s)
+	i := strings.IndexFunc(t, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(t)
+	}
+	num, unit := t[:i], strings.ToLower(strings.TrimSpace(t[i:]))
+	mult, ok := byteUnits[unit]
+	if !ok || num == "" {
+		return 0, fmt.Errorf("sanity: invalid byte size %q", s)
+	}
+	if n, err := strconv.ParseInt(num, 10, 64); err == nil {
+		if n > math.MaxInt64/int64(mult) {
+			return 0, fmt.Errorf("sanity: byte size %q overflows int64", s)
+		}
+		return ByteSize(n) * mult, nil
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("sanity: invalid byte size %q", s)
+	}
+	v := f * float64(mult)
+	if v >= math.MaxInt64 {
+		return 0, fmt.Errorf("sanity: byte size %q overflows int64", s)
+	}
+	return ByteSize(v), nil
+}
+
+// ParseBytesOr returns ParseBytes(s), or def if s is blank or invalid.
+func ParseBytesOr(s string, def ByteSize) ByteSize {
+	b, err := ParseBytes(s)
+	if err != nil {
+		return def
+	}
+	return b
+}
+
+// ClampBytes clamps *p into [min,max] (Clamp).
+func ClampBytes(p *ByteSize, min, max ByteSize) {
+	Clamp(p, min, max)
+}
+
+// InRangeBytes checks min <= v <= max; the OutOfRangeError[ByteSize] renders
+// sizes human-readably ("must be in [1MiB,1GiB], got 2GiB").
+func InRangeBytes(name string, v, min, max ByteSize) error {
+	return InRangeNum(name, v, min, max)
+}
@@ -0,0 +1,85 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestByteSize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "ParseBytes units",
+			function: func() interface{} {
+				var out []sanity.ByteSize
+				for _, s := range []string{"512MiB", "1.5GB", "64k", "100", " 2 gi ", "1KiB", "0"} {
+					b, err := sanity.ParseBytes(s)
+					if err != nil {
+						return err
+					}
+					out = append(out, b)
+				}
+				return out
+			},
+			expected: []sanity.ByteSize{512 * sanity.MiB, 1500 * sanity.MB, 64 * sanity.KB, 100, 2 * sanity.GiB, 1024, 0},
+		},
+		{
+			name: "ParseBytes rejects junk and overflow",
+			function: func() interface{} {
+				var bad []bool
+				for _, s := range []string{"", "MiB", "-1MiB", "12XB", "1.2.3k", "9999999PiB"} {
+					_, err := sanity.ParseBytes(s)
+					bad = append(bad, err != nil)
+				}
+				return bad
+			},
+			expected: []bool{true, true, true, true, true, true},
+		},
+		{
+			name: "String is human-readable",
+			function: func() interface{} {
+				return []string{
+					(512 * sanity.MiB).String(), sanity.ByteSize(1536).String(), sanity.ByteSize(100).String(),
+					(-2 * sanity.GiB).String(), sanity.ByteSize(1000).String(),
+				}
+			},
+			expected: []string{"512MiB", "1.5KiB", "100B", "-2GiB", "1000B"},
+		},
+		{
+			name: "ParseBytesOr and ClampBytes",
+			function: func() interface{} {
+				b := sanity.ParseBytesOr("64GiB", sanity.GiB)
+				sanity.ClampBytes(&b, sanity.MiB, 16*sanity.GiB)
+				return []sanity.ByteSize{b, sanity.ParseBytesOr("lots", sanity.GiB)}
+			},
+			expected: []sanity.ByteSize{16 * sanity.GiB, sanity.GiB},
+		},
+		{
+			name: "InRangeBytes renders sizes",
+			function: func() interface{} {
+				err := sanity.InRangeBytes("mem", 2*sanity.GiB, sanity.MiB, sanity.GiB)
+				verbose := err.Error()
+				return []interface{}{
+					errors.Is(err, sanity.ErrOutOfRange),
+					verbose == "mem: must be in [1MiB,1GiB], got 2GiB" || sanity.RedactBuild,
+					sanity.Redacted(err).Error(),
+					sanity.InRangeBytes("mem", sanity.MiB, sanity.MiB, sanity.GiB) == nil,
+				}
+			},
+			expected: []interface{}{true, true, "mem: must be in [1MiB,1GiB]", true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sessaidi/sanity"
+)
+
+// lint implements "sanity lint" and returns the exit status.
+func lint(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	rulesPath := fs.String("rules", "", "rules file, JSON or YAML (required)")
+	color := fs.Bool("color", false, "highlight the report with ANSI colors")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: sanity lint -rules=file [-color] config")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *rulesPath == "" || fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	violations, err := lintFile(fs.Arg(0), *rulesPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if violations == nil {
+		return 0
+	}
+	var opts []sanity.ReportOption
+	if *color {
+		opts = append(opts, sanity.ReportColor())
+	}
+	fmt.Fprintf(stdout, "%s: %s\n", fs.Arg(0), sanity.Report(violations, opts...))
+	return 1
+}
+
+// lintFile validates the config at path against the rules at rulesPath and
+// returns the violations, or err if either file cannot be used.
+func lintFile(path, rulesPath string) (violations, err error) {
+	var tags map[string]string
+	if err := decodeFile(rulesPath, &tags); err != nil {
+		return nil, err
+	}
+	var cfg any
+	if err := decodeFile(path, &cfg); err != nil {
+		return nil, err
+	}
+	top, ok := cfg.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: top level is not a mapping", path)
+	}
+	values := make(map[string]any)
+	flatten("", top, values)
+	malformed := parseDurations(tags, values)
+	violations = sanity.RulesFromTags(tags).Validate(values)
+	if errors.Is(violations, sanity.ErrBadTag) {
+		return nil, fmt.Errorf("%s: %w", rulesPath, violations)
+	}
+	return sanity.Join(malformed, violations), nil
+}
+
+// parseDurations converts the string values of fields whose min/max bounds
+// are durations ("timeout: max=1m") to time.Duration, since JSON and YAML have
+// no duration type. A value that does not parse is reported as a FormatError
+// and its rules are dropped from tags.
+func parseDurations(tags map[string]string, values map[string]any) error {
+	g := sanity.NewGuard(sanity.WithMaxErrors(0))
+	for _, name := range slices.Sorted(maps.Keys(tags)) {
+		s, ok := values[name].(string)
+		if !ok || !hasDurationBound(tags[name]) {
+			continue
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			g.Add(sanity.FormatError{Field: name, Format: sanity.FormatDuration, Got: s})
+			delete(tags, name)
+			continue
+		}
+		values[name] = d
+	}
+	return g.Err()
+}
+
+// hasDurationBound reports whether spec has a min or max bound written as a
+// duration with a unit, such as "1m"; plain numbers stay numeric bounds.
+func hasDurationBound(spec string) bool {
+	for _, part := range strings.Split(spec, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if key != "min" && key != "max" {
+			continue
+		}
+		if _, err := strconv.ParseFloat(val, 64); err == nil {
+			continue
+		}
+		if _, err := time.ParseDuration(val); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(data, v)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, v)
+	default:
+		return fmt.Errorf("%s: unknown format %q", path, ext)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// flatten records every value of m in out under its dotted path, descending
+// into nested mappings; a mapping is recorded itself as well, so rules such as
+// nonempty can apply to it.
+func flatten(prefix string, m map[string]any, out map[string]any) {
+	for k, v := range m {
+		path := sanity.JoinPath(prefix, k)
+		out[path] = v
+		if sub, ok := v.(map[string]any); ok {
+			flatten(path, sub, out)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const rules = `port: required,nonzero,min=1,max=65535
+mode: oneof=auto|manual
+db.host: required,nonblank
+db.replicas: minlen=1
+`
+
+func write(t *testing.T, name, data string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLint(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Valid YAML config",
+			function: func() interface{} {
+				cfg := write(t, "c.yaml", "port: 8080\nmode: auto\ndb:\n  host: db1\n  replicas: [a]\n")
+				var out, errOut bytes.Buffer
+				code := run([]string{"lint", "-rules", write(t, "r.yaml", rules), cfg}, &out, &errOut)
+				return []interface{}{code, out.String(), errOut.String()}
+			},
+			expected: []interface{}{0, "", ""},
+		},
+		{
+			name: "Violations in a JSON config are reported",
+			function: func() interface{} {
+				cfg := write(t, "c.json", `{"port": 0, "mode": "x", "db": {"host": " ", "replicas": ["a"]}}`)
+				var out bytes.Buffer
+				code := run([]string{"lint", "-rules=" + write(t, "r.yaml", rules), cfg}, &out, &bytes.Buffer{})
+				return []interface{}{code, strings.TrimPrefix(out.String(), cfg+": ")}
+			},
+			expected: []interface{}{1, "3 errors in 3 fields\n" +
+				"db.host\n  1. must be non-empty [NON_EMPTY]\n" +
+				"mode\n  2. must be one of [auto, manual] [NOT_IN_SET]\n" +
+				"port\n  3. must be non-zero [NON_ZERO]\n"},
+		},
+		{
+			name: "Missing required keys",
+			function: func() interface{} {
+				cfg := write(t, "c.yaml", "mode: auto\n")
+				var out bytes.Buffer
+				code := run([]string{"lint", "-rules", write(t, "r.yaml", rules), cfg}, &out, &bytes.Buffer{})
+				return []interface{}{code, strings.TrimPrefix(out.String(), cfg+": ")}
+			},
+			expected: []interface{}{1, "2 errors in 2 fields\n" +
+				"db.host\n  1. is required [MISSING_KEY]\n" +
+				"port\n  2. is required [MISSING_KEY]\n"},
+		},
+		{
+			name: "Duration bounds parse string values",
+			function: func() interface{} {
+				durations := write(t, "r.yaml", "timeout: required,min=1s,max=1m\nretry: max=10s\n")
+				var codes []int
+				var out bytes.Buffer
+				codes = append(codes, run([]string{"lint", "-rules", durations, write(t, "c.yaml", "timeout: 30s\nretry: 0s\n")}, &out, &bytes.Buffer{}))
+				cfg := write(t, "c.json", `{"timeout": "5m", "retry": "soon"}`)
+				codes = append(codes, run([]string{"lint", "-rules", durations, cfg}, &out, &bytes.Buffer{}))
+				return []interface{}{codes, strings.TrimPrefix(out.String(), cfg+": ")}
+			},
+			expected: []interface{}{[]int{0, 1}, "2 errors in 2 fields\n" +
+				"retry\n  1. must be a valid duration, got \"soon\" [INVALID_FORMAT]\n" +
+				"timeout\n  2. must be in [1s,1m0s], got 5m0s [OUT_OF_RANGE]\n"},
+		},
+		{
+			name: "Malformed rules and unreadable files exit with 2",
+			function: func() interface{} {
+				cfg := write(t, "c.yaml", "port: 1\n")
+				var codes []int
+				codes = append(codes, run([]string{"lint", "-rules", write(t, "r.yaml", "port: bogus\n"), cfg}, &bytes.Buffer{}, &bytes.Buffer{}))
+				codes = append(codes, run([]string{"lint", "-rules", write(t, "r.yaml", rules), "missing.yaml"}, &bytes.Buffer{}, &bytes.Buffer{}))
+				codes = append(codes, run([]string{"lint", "-rules", write(t, "r.toml", rules), cfg}, &bytes.Buffer{}, &bytes.Buffer{}))
+				codes = append(codes, run([]string{"lint", cfg}, &bytes.Buffer{}, &bytes.Buffer{}))
+				codes = append(codes, run([]string{"check"}, &bytes.Buffer{}, &bytes.Buffer{}))
+				return codes
+			},
+			expected: []int{2, 2, 2, 2, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Command sanity validates configuration files without writing Go.
+//
+//	sanity lint -rules=rules.yaml config.yaml
+//
+// The rules file (JSON or YAML) maps field paths of the config to rules in the
+// grammar of sanity.RulesFromTags: `sanity` struct tag rules plus "required"
+// for keys that must be present. Nested config keys are addressed with dots:
+//
+//	port: required,nonzero,min=1,max=65535
+//	mode: oneof=auto|manual
+//	db.host: required,nonblank
+//	db.replicas: minlen=1
+//	timeout: max=1m
+//
+// JSON and YAML have no duration type, so a string value whose min or max
+// bound is a duration ("1m") is parsed with time.ParseDuration first; a value
+// that does not parse is a violation of that field.
+//
+// Config files are decoded by extension (.json, .yaml, .yml). Violations are
+// printed as a sanity.Report and the exit status is 1; unreadable files and
+// malformed rules exit with status 2.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "lint" {
+		fmt.Fprintln(stderr, "usage: sanity lint -rules=file [-color] config")
+		return 2
+	}
+	return lint(args[1:], stdout, stderr)
+}
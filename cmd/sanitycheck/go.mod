@@ -0,0 +1,15 @@
+module github.com/sessaidi/sanity/cmd/sanitycheck
+
+go 1.24
+
+require (
+	github.com/sessaidi/sanity/sanitycheck v0.4.0
+	golang.org/x/tools v0.36.0
+)
+
+require (
+	golang.org/x/mod v0.27.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+)
+
+replace github.com/sessaidi/sanity/sanitycheck => ../../sanitycheck
@@ -0,0 +1,13 @@
+// Command sanitycheck runs the sanitycheck analyzer, standalone or as a vet tool:
+//
+//	go install github.com/sessaidi/sanity/cmd/sanitycheck@latest
+//	go vet -vettool=$(which sanitycheck) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/sessaidi/sanity/sanitycheck"
+)
+
+func main() { singlechecker.Main(sanitycheck.Analyzer) }
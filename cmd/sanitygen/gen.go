@@ -0,0 +1,655 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const sanityImport = "github.com/sessaidi/sanity"
+
+var errUnsupported = errors.New("unsupported")
+
+type kind int
+
+const (
+	kOther kind = iota
+	kString
+	kBool
+	kInt
+	kUint
+	kFloat
+	kDuration
+	kPointer
+	kSlice
+	kMap
+	kInterface
+	kStruct
+)
+
+// fieldType is what the generator needs to know about a field's type,
+// resolved from the AST: builtin and time.Duration types, composites, and
+// named types declared in the package.
+type fieldType struct {
+	kind kind
+	name string     // Go spelling, e.g. "int", "Mode", "time.Duration"
+	bits string     // integer width suffix for math constants: "", "8", ..., "64"
+	elem *fieldType // pointer, slice or map element
+}
+
+// generator emits SanityDefaults and SanityValidate methods for struct types
+// of one package.
+type generator struct {
+	pkg     string
+	decls   map[string]ast.Expr // package-level type name -> type expression
+	targets map[string]bool
+	imports map[string]bool // standard library imports of the output
+	buf     bytes.Buffer
+}
+
+// generate returns the formatted source of SanityDefaults and SanityValidate
+// methods for the named struct types declared in files. Nested struct fields
+// must have their types listed as well.
+func generate(pkg string, files []*ast.File, typeNames []string) ([]byte, error) {
+	g := &generator{
+		pkg:     pkg,
+		decls:   make(map[string]ast.Expr),
+		targets: make(map[string]bool),
+		imports: make(map[string]bool),
+	}
+	for _, f := range files {
+		for _, d := range f.Decls {
+			gd, ok := d.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range gd.Specs {
+				ts := s.(*ast.TypeSpec)
+				g.decls[ts.Name.Name] = ts.Type
+			}
+		}
+	}
+	for _, name := range typeNames {
+		if _, ok := g.decls[name].(*ast.StructType); !ok {
+			return nil, fmt.Errorf("sanitygen: %s: struct type not found", name)
+		}
+		g.targets[name] = true
+	}
+	for _, name := range typeNames {
+		st := g.decls[name].(*ast.StructType)
+		if err := g.defaults(name, st); err != nil {
+			return nil, err
+		}
+		if err := g.validate(name, st); err != nil {
+			return nil, err
+		}
+	}
+	return g.source()
+}
+
+func (g *generator) source() ([]byte, error) {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by sanitygen; DO NOT EDIT.\n\npackage %s\n\nimport (\n", g.pkg)
+	std := make([]string, 0, len(g.imports))
+	for p := range g.imports {
+		std = append(std, p)
+	}
+	slices.Sort(std)
+	for _, p := range std {
+		fmt.Fprintf(&out, "\t%q\n", p)
+	}
+	if len(std) > 0 {
+		out.WriteString("\n")
+	}
+	fmt.Fprintf(&out, "\t%q\n)\n", sanityImport)
+	out.Write(g.buf.Bytes())
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("sanitygen: formatting output: %w", err)
+	}
+	return src, nil
+}
+
+// field is one exported struct field with its resolved type and tags.
+type field struct {
+	goName   string
+	name     string // json name, else Go name; "" for embedded fields
+	typ      fieldType
+	tag      reflect.StructTag
+	embedded bool
+}
+
+func (g *generator) fields(typeName string, st *ast.StructType) ([]field, error) {
+	var out []field
+	for _, f := range st.Fields.List {
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			s, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return nil, fmt.Errorf("sanitygen: %s: bad tag %s", typeName, f.Tag.Value)
+			}
+			tag = reflect.StructTag(s)
+		}
+		ft, err := g.resolve(f.Type, 0)
+		if err != nil {
+			return nil, err
+		}
+		names := f.Names
+		embedded := len(names) == 0
+		if embedded {
+			names = []*ast.Ident{ast.NewIdent(embeddedName(f.Type))}
+		}
+		for _, n := range names {
+			if !n.IsExported() {
+				continue
+			}
+			fd := field{goName: n.Name, name: jsonName(tag, n.Name), typ: ft, tag: tag, embedded: embedded}
+			if embedded {
+				fd.name = ""
+			}
+			out = append(out, fd)
+		}
+	}
+	return out, nil
+}
+
+func embeddedName(e ast.Expr) string {
+	if s, ok := e.(*ast.StarExpr); ok {
+		e = s.X
+	}
+	if s, ok := e.(*ast.SelectorExpr); ok {
+		return s.Sel.Name
+	}
+	return types.ExprString(e)
+}
+
+func jsonName(tag reflect.StructTag, goName string) string {
+	if j := tag.Get("json"); j != "" {
+		if name, _, _ := strings.Cut(j, ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return goName
+}
+
+var builtinKinds = map[string]struct {
+	kind kind
+	bits string
+}{
+	"string": {kString, ""}, "bool": {kBool, ""},
+	"int": {kInt, ""}, "int8": {kInt, "8"}, "int16": {kInt, "16"}, "int32": {kInt, "32"}, "int64": {kInt, "64"},
+	"rune": {kInt, "32"},
+	"uint": {kUint, ""}, "uint8": {kUint, "8"}, "uint16": {kUint, "16"}, "uint32": {kUint, "32"}, "uint64": {kUint, "64"},
+	"uintptr": {kUint, "64"}, "byte": {kUint, "8"},
+	"float32": {kFloat, "32"}, "float64": {kFloat, "64"},
+}
+
+func (g *generator) resolve(e ast.Expr, depth int) (fieldType, error) {
+	ft := fieldType{name: types.ExprString(e)}
+	if depth > 16 {
+		return ft, fmt.Errorf("sanitygen: type %s: too deeply nested", ft.name)
+	}
+	switch t := e.(type) {
+	case *ast.Ident:
+		if b, ok := builtinKinds[t.Name]; ok {
+			ft.kind, ft.bits = b.kind, b.bits
+			return ft, nil
+		}
+		switch d := g.decls[t.Name].(type) {
+		case nil:
+		case *ast.StructType:
+			ft.kind = kStruct
+		default:
+			under, err := g.resolve(d, depth+1)
+			if err != nil {
+				return ft, err
+			}
+			under.name = ft.name
+			return under, nil
+		}
+	case *ast.SelectorExpr:
+		if ft.name == "time.Duration" {
+			ft.kind = kDuration
+		}
+	case *ast.StarExpr, *ast.ArrayType, *ast.MapType:
+		var elem ast.Expr
+		switch t := t.(type) {
+		case *ast.StarExpr:
+			ft.kind, elem = kPointer, t.X
+		case *ast.ArrayType:
+			if t.Len != nil {
+				return ft, nil // arrays are not supported
+			}
+			ft.kind, elem = kSlice, t.Elt
+		case *ast.MapType:
+			ft.kind, elem = kMap, t.Value
+		}
+		et, err := g.resolve(elem, depth+1)
+		if err != nil {
+			return ft, err
+		}
+		ft.elem = &et
+	case *ast.InterfaceType:
+		ft.kind = kInterface
+	}
+	return ft, nil
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// defaults emits SanityDefaults, mirroring sanity.ApplyDefaults.
+func (g *generator) defaults(typeName string, st *ast.StructType) error {
+	fields, err := g.fields(typeName, st)
+	if err != nil {
+		return err
+	}
+	g.printf("\n// SanityDefaults fills zero fields of c from their default tags.\nfunc (c *%s) SanityDefaults() {\n", typeName)
+	for _, f := range fields {
+		def, hasDef := f.tag.Lookup("default")
+		v := "c." + f.goName
+		ft := f.typ
+		switch {
+		case ft.kind == kPointer && ft.elem.kind == kStruct:
+			if err := g.checkTarget(typeName, f, ft.elem); err != nil {
+				return err
+			}
+			if hasDef {
+				g.printf("if %s == nil {\n%s = new(%s)\n}\n%s.SanityDefaults()\n", v, v, ft.elem.name, v)
+			} else {
+				g.printf("if %s != nil {\n%s.SanityDefaults()\n}\n", v, v)
+			}
+		case ft.kind == kStruct:
+			if err := g.checkTarget(typeName, f, &ft); err != nil {
+				return err
+			}
+			g.printf("%s.SanityDefaults()\n", v)
+		case !hasDef:
+		case ft.kind == kPointer:
+			lit, err := g.literal(*ft.elem, def)
+			if err != nil {
+				return fieldErr(typeName, f, "default", def, err)
+			}
+			g.printf("if %s == nil {\n%s = sanity.Ptr[%s](%s)\n}\n", v, v, ft.elem.name, lit)
+		case ft.kind == kSlice:
+			var lits []string
+			for _, p := range strings.Split(def, ",") {
+				lit, err := g.literal(*ft.elem, strings.TrimSpace(p))
+				if err != nil {
+					return fieldErr(typeName, f, "default", def, err)
+				}
+				lits = append(lits, lit)
+			}
+			g.printf("if %s == nil {\n%s = %s{%s}\n}\n", v, v, ft.name, strings.Join(lits, ", "))
+		default:
+			lit, err := g.literal(ft, def)
+			if err != nil {
+				return fieldErr(typeName, f, "default", def, err)
+			}
+			g.printf("sanity.SetIfZero(&%s, %s)\n", v, lit)
+		}
+	}
+	g.printf("}\n")
+	return nil
+}
+
+// checkTarget reports a nested struct whose type is not being generated.
+func (g *generator) checkTarget(typeName string, f field, ft *fieldType) error {
+	if !g.targets[ft.name] {
+		return fmt.Errorf("sanitygen: %s.%s: nested struct %s must be listed in -type", typeName, f.goName, ft.name)
+	}
+	return nil
+}
+
+func fieldErr(typeName string, f field, what, val string, err error) error {
+	return fmt.Errorf("sanitygen: %s.%s: %s %q: %w", typeName, f.goName, what, val, err)
+}
+
+// literal returns the Go expression of s, a tag value, for a value of type ft.
+func (g *generator) literal(ft fieldType, s string) (string, error) {
+	var err error
+	switch ft.kind {
+	case kString:
+		return strconv.Quote(s), nil
+	case kBool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		return strconv.FormatBool(b), err
+	case kInt:
+		var n int64
+		n, err = strconv.ParseInt(s, 0, bitSize(ft))
+		return strconv.FormatInt(n, 10), err
+	case kUint:
+		var n uint64
+		n, err = strconv.ParseUint(s, 0, bitSize(ft))
+		return strconv.FormatUint(n, 10), err
+	case kFloat:
+		var f float64
+		f, err = strconv.ParseFloat(s, bitSize(ft))
+		return strconv.FormatFloat(f, 'g', -1, 64), err
+	case kDuration:
+		var d time.Duration
+		d, err = time.ParseDuration(s)
+		return g.durationLiteral(d), err
+	default:
+		return "", fmt.Errorf("%w type %s", errUnsupported, ft.name)
+	}
+}
+
+func bitSize(ft fieldType) int {
+	if ft.bits == "" {
+		return 64
+	}
+	n, _ := strconv.Atoi(ft.bits)
+	return n
+}
+
+// durationLiteral spells d in the largest whole unit ("90 * time.Second").
+func (g *generator) durationLiteral(d time.Duration) string {
+	g.imports["time"] = true
+	units := []struct {
+		d    time.Duration
+		name string
+	}{{time.Hour, "Hour"}, {time.Minute, "Minute"}, {time.Second, "Second"}, {time.Millisecond, "Millisecond"}, {time.Microsecond, "Microsecond"}}
+	for _, u := range units {
+		if d != 0 && d%u.d == 0 {
+			return fmt.Sprintf("%d * time.%s", d/u.d, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}
+
+// rules is the parsed form of a sanity tag, as in sanity.ValidateStruct.
+type rules struct {
+	notNil, nonZero, nonEmpty, nonBlank bool
+	minLen                              string
+	min, max                            string
+	hasMin, hasMax                      bool
+	oneOf                               []string
+}
+
+func parseRules(tag string) (rules, error) {
+	var r rules
+	if tag == "" {
+		return r, nil
+	}
+	for _, part := range strings.Split(tag, ",") {
+		key, val, hasVal := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "minlen", "min", "max", "oneof":
+			if !hasVal {
+				return r, fmt.Errorf("rule %q needs a value", key)
+			}
+		}
+		switch key {
+		case "notnil":
+			r.notNil = true
+		case "nonzero":
+			r.nonZero = true
+		case "nonempty":
+			r.nonEmpty = true
+		case "nonblank":
+			r.nonBlank = true
+		case "minlen":
+			if _, err := strconv.Atoi(val); err != nil {
+				return r, fmt.Errorf("rule %q: %v", part, err)
+			}
+			r.minLen = val
+		case "min":
+			r.hasMin, r.min = true, val
+		case "max":
+			r.hasMax, r.max = true, val
+		case "oneof":
+			r.oneOf = strings.Split(val, "|")
+		default:
+			return r, fmt.Errorf("unknown rule %q", key)
+		}
+	}
+	return r, nil
+}
+
+// clause is one branch of a field's if/else-if chain; an empty cond is the
+// final else.
+type clause struct {
+	cond, body string
+}
+
+func failure(expr string) clause {
+	return clause{cond: "err := " + expr + "; err != nil", body: "errs = append(errs, err)"}
+}
+
+// validate emits SanityValidate, mirroring sanity.ValidateStruct: rules run in
+// the same order and only the first failing rule of each field is reported.
+// Failures are collected in a stack array sized for one per field, so a valid
+// value costs no allocation.
+func (g *generator) validate(typeName string, st *ast.StructType) error {
+	fields, err := g.fields(typeName, st)
+	if err != nil {
+		return err
+	}
+	outer := g.buf
+	g.buf = bytes.Buffer{}
+	n := 0
+	for _, f := range fields {
+		tag := f.tag.Get("sanity")
+		if tag == "-" {
+			continue
+		}
+		r, err := parseRules(tag)
+		if err != nil {
+			return fmt.Errorf("sanitygen: %s.%s: %w", typeName, f.goName, err)
+		}
+		emitted, err := g.validateField(typeName, f, r)
+		if err != nil {
+			return err
+		}
+		if emitted {
+			n++
+		}
+	}
+	body := g.buf
+	g.buf = outer
+	g.printf("\n// SanityValidate checks c against its sanity tags and returns the aggregate\n// of all failures.\n")
+	g.printf("func (c *%s) SanityValidate() error {\n", typeName)
+	if n == 0 {
+		g.printf("return nil\n}\n")
+		return nil
+	}
+	g.printf("var buf [%d]error\nerrs := buf[:0]\n", n)
+	g.buf.Write(body.Bytes())
+	g.printf("return sanity.Join(errs...)\n}\n")
+	return nil
+}
+
+// validateField emits the checks of one field and reports whether there were any.
+func (g *generator) validateField(typeName string, f field, r rules) (bool, error) {
+	v, name, ft := "c."+f.goName, strconv.Quote(f.name), f.typ
+	var pre []clause
+	nilable := ft.kind == kPointer || ft.kind == kSlice || ft.kind == kMap || ft.kind == kInterface
+	switch {
+	case r.notNil && nilable:
+		pre = append(pre, clause{v + " == nil", fmt.Sprintf("errs = append(errs, sanity.NotNilError{Field: %s})", name)})
+	case r.nonZero && nilable:
+		pre = append(pre, clause{v + " == nil", fmt.Sprintf("errs = append(errs, sanity.NonZeroError{Field: %s})", name)})
+	case r.nonZero && ft.kind == kStruct:
+		return false, fmt.Errorf("sanitygen: %s.%s: nonzero on struct: %w", typeName, f.goName, errUnsupported)
+	case r.nonZero && ft.kind != kOther:
+		pre = append(pre, failure(fmt.Sprintf("sanity.NonZero(%s, %s)", name, v)))
+	}
+
+	// Optional fields: a nil pointer passes the value rules; otherwise they apply to the pointee.
+	et, ev, guarded := ft, v, false
+	if ft.kind == kPointer {
+		et, ev = *ft.elem, "*"+v
+		guarded = len(pre) == 0
+	}
+	value, err := g.valueClauses(et, ev, name, r)
+	if err != nil {
+		return false, fmt.Errorf("sanitygen: %s.%s: %w", typeName, f.goName, err)
+	}
+	if et.kind == kStruct {
+		if err := g.checkTarget(typeName, f, &et); err != nil {
+			return false, err
+		}
+		call := fmt.Sprintf("errs = append(errs, sanity.WithPrefix(%s, %s.SanityValidate()))", name, v)
+		if ft.kind == kPointer {
+			value = append(value, clause{v + " != nil", call})
+			guarded = false
+		} else {
+			value = append(value, clause{"", call})
+		}
+	}
+	if guarded && len(value) > 0 {
+		g.printf("if %s != nil {\n", v)
+		g.chain(value)
+		g.printf("}\n")
+		return true, nil
+	}
+	cs := append(pre, value...)
+	g.chain(cs)
+	return len(cs) > 0, nil
+}
+
+func (g *generator) chain(cs []clause) {
+	for i, c := range cs {
+		switch {
+		case c.cond == "" && i == 0:
+			g.printf("%s\n", c.body)
+			continue
+		case c.cond == "":
+			g.printf(" else {\n%s\n}", c.body)
+		case i == 0:
+			g.printf("if %s {\n%s\n}", c.cond, c.body)
+		default:
+			g.printf(" else if %s {\n%s\n}", c.cond, c.body)
+		}
+		if i == len(cs)-1 {
+			g.printf("\n")
+		}
+	}
+}
+
+// valueClauses returns the checks of the value rules (everything but notnil
+// and nonzero) for v of type ft.
+func (g *generator) valueClauses(ft fieldType, v, name string, r rules) ([]clause, error) {
+	var cs []clause
+	str := v
+	if ft.name != "string" {
+		str = "string(" + v + ")"
+	}
+	if r.nonEmpty {
+		switch ft.kind {
+		case kString:
+			cs = append(cs, failure(fmt.Sprintf("sanity.NonEmpty(%s, %s)", name, str)))
+		case kSlice:
+			cs = append(cs, failure(fmt.Sprintf("sanity.SliceLenAtLeast(%s, %s, 1)", name, v)))
+		case kMap:
+			cs = append(cs, failure(fmt.Sprintf("sanity.MapLenAtLeast(%s, %s, 1)", name, v)))
+		}
+	}
+	if r.nonBlank && ft.kind == kString {
+		cs = append(cs, failure(fmt.Sprintf("sanity.NonBlank(%s, %s)", name, str)))
+	}
+	if r.minLen != "" {
+		switch ft.kind {
+		case kString:
+			cs = append(cs, failure(fmt.Sprintf("sanity.StrLenAtLeast(%s, %s, %s)", name, str, r.minLen)))
+		case kSlice:
+			cs = append(cs, failure(fmt.Sprintf("sanity.SliceLenAtLeast(%s, %s, %s)", name, v, r.minLen)))
+		case kMap:
+			cs = append(cs, failure(fmt.Sprintf("sanity.MapLenAtLeast(%s, %s, %s)", name, v, r.minLen)))
+		}
+	}
+	if r.hasMin || r.hasMax {
+		c, err := g.rangeClause(ft, v, name, r)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, c)
+	}
+	if r.oneOf != nil {
+		c, err := g.oneOfClause(ft, v, name, r.oneOf)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
+
+func (g *generator) rangeClause(ft fieldType, v, name string, r rules) (clause, error) {
+	bound := func(has bool, s, missing string) (string, error) {
+		if !has {
+			g.imports["math"] = true
+			return missing, nil
+		}
+		lit, err := g.literal(ft, s)
+		if err != nil {
+			return "", fmt.Errorf("bound %q: %w", s, err)
+		}
+		return lit, nil
+	}
+	var fn, lo, hi string
+	switch ft.kind {
+	case kDuration:
+		fn, lo, hi = "InRangeDuration", "math.MinInt64", "math.MaxInt64"
+	case kInt:
+		fn, lo, hi = "InRangeNum", "math.MinInt"+ft.bits, "math.MaxInt"+ft.bits
+	case kUint:
+		fn, lo, hi = "InRangeNum", "0", "math.MaxUint"+ft.bits
+		if ft.name == "uintptr" {
+			hi = "math.MaxUint64"
+		}
+	case kFloat:
+		fn, lo, hi = "InRangeFloat64", "math.Inf(-1)", "math.Inf(1)"
+		v = "float64(" + v + ")"
+	default:
+		return clause{}, fmt.Errorf("min/max on non-numeric %s: %w", ft.name, errUnsupported)
+	}
+	min, err := bound(r.hasMin, r.min, lo)
+	if err != nil {
+		return clause{}, err
+	}
+	max, err := bound(r.hasMax, r.max, hi)
+	if err != nil {
+		return clause{}, err
+	}
+	return failure(fmt.Sprintf("sanity.%s(%s, %s, %s, %s)", fn, name, v, min, max)), nil
+}
+
+func (g *generator) oneOfClause(ft fieldType, v, name string, allowed []string) (clause, error) {
+	lits := make([]string, 0, len(allowed))
+	switch ft.kind {
+	case kString:
+		for _, a := range allowed {
+			lits = append(lits, strconv.Quote(a))
+		}
+	case kDuration:
+		// ValidateStruct compares the %v form ("1m0s"), so do the same.
+		v = v + ".String()"
+		for _, a := range allowed {
+			lits = append(lits, strconv.Quote(a))
+		}
+	case kBool, kInt, kUint, kFloat:
+		for _, a := range allowed {
+			lit, err := g.literal(ft, a)
+			if err != nil {
+				return clause{}, fmt.Errorf("oneof value %q: %w", a, err)
+			}
+			lits = append(lits, lit)
+		}
+	default:
+		return clause{}, fmt.Errorf("oneof on %s: %w", ft.name, errUnsupported)
+	}
+	return failure(fmt.Sprintf("sanity.OneOf(%s, %s, %s)", name, v, strings.Join(lits, ", "))), nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const source = `package cfg
+
+import "time"
+
+type Mode string
+
+type TLS struct {
+	Cert string ` + "`json:\"cert\" sanity:\"nonblank\"`" + `
+}
+
+type Config struct {
+	Port    int           ` + "`json:\"port\" default:\"8080\" sanity:\"nonzero,min=1,max=65535\"`" + `
+	Mode    Mode          ` + "`json:\"mode\" default:\"auto\" sanity:\"oneof=auto|manual\"`" + `
+	Timeout time.Duration ` + "`default:\"90s\" sanity:\"max=1m\"`" + `
+	Hosts   []string      ` + "`default:\"a, b\" sanity:\"nonempty\"`" + `
+	Limit   *int          ` + "`sanity:\"min=1\"`" + `
+	TLS     *TLS          ` + "`json:\"tls\" default:\"{}\"`" + `
+	Skip    int           ` + "`sanity:\"-\"`" + `
+}
+
+type Bad struct {
+	Name string ` + "`sanity:\"max=3\"`" + `
+}
+
+type Outer struct {
+	Inner TLS
+}
+`
+
+func parse(t *testing.T) []*ast.File {
+	f, err := parser.ParseFile(token.NewFileSet(), "cfg.go", source, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []*ast.File{f}
+}
+
+func TestGenerate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Defaults mirror ApplyDefaults",
+			function: func() interface{} {
+				src, err := generate("cfg", parse(t), []string{"Config", "TLS"})
+				out := string(src)
+				return []bool{
+					err == nil,
+					strings.Contains(out, "sanity.SetIfZero(&c.Port, 8080)"),
+					strings.Contains(out, `sanity.SetIfZero(&c.Mode, "auto")`),
+					strings.Contains(out, "sanity.SetIfZero(&c.Timeout, 90*time.Second)"),
+					strings.Contains(out, "c.Hosts = []string{\"a\", \"b\"}"),
+					strings.Contains(out, "c.TLS = new(TLS)\n\t}\n\tc.TLS.SanityDefaults()"),
+				}
+			},
+			expected: []bool{true, true, true, true, true, true},
+		},
+		{
+			name: "Validation chains first-failing rules per field",
+			function: func() interface{} {
+				src, _ := generate("cfg", parse(t), []string{"Config", "TLS"})
+				out := string(src)
+				return []bool{
+					strings.Contains(out, "var buf [6]error"),
+					strings.Contains(out, `} else if err := sanity.InRangeNum("port", c.Port, 1, 65535); err != nil {`),
+					strings.Contains(out, `sanity.OneOf("mode", c.Mode, "auto", "manual")`),
+					strings.Contains(out, `sanity.InRangeDuration("Timeout", c.Timeout, math.MinInt64, 1*time.Minute)`),
+					strings.Contains(out, `sanity.SliceLenAtLeast("Hosts", c.Hosts, 1)`),
+					strings.Contains(out, "if c.Limit != nil {\n\t\tif err := sanity.InRangeNum(\"Limit\", *c.Limit, 1, math.MaxInt)"),
+					strings.Contains(out, `errs = append(errs, sanity.WithPrefix("tls", c.TLS.SanityValidate()))`),
+					strings.Contains(out, "return sanity.Join(errs...)"),
+					strings.Contains(out, "Skip"),
+				}
+			},
+			expected: []bool{true, true, true, true, true, true, true, true, false},
+		},
+		{
+			name: "Imports grouped, standard library first",
+			function: func() interface{} {
+				src, _ := generate("cfg", parse(t), []string{"TLS"})
+				head, _, _ := strings.Cut(string(src), ")")
+				return head
+			},
+			expected: "// Code generated by sanitygen; DO NOT EDIT.\n\npackage cfg\n\nimport (\n\t\"github.com/sessaidi/sanity\"\n",
+		},
+		{
+			name: "Errors",
+			function: func() interface{} {
+				_, missing := generate("cfg", parse(t), []string{"Nope"})
+				_, bad := generate("cfg", parse(t), []string{"Bad"})
+				_, nested := generate("cfg", parse(t), []string{"Outer"})
+				return []bool{
+					missing != nil,
+					errors.Is(bad, errUnsupported),
+					nested != nil && strings.Contains(nested.Error(), "must be listed"),
+				}
+			},
+			expected: []bool{true, true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
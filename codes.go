@@ -0,0 +1,83 @@
+package sanity
+
+import "errors"
+
+// Stable machine-readable codes reported by Code() and CodeOf.
+const (
+	CodeNotNil          = "NOT_NIL"
+	CodeNonZero         = "NON_ZERO"
+	CodeNonEmpty        = "NON_EMPTY"
+	CodeLenAtLeast      = "LEN_AT_LEAST"
+	CodeLenAtMost       = "LEN_AT_MOST"
+	CodeLenBetween      = "LEN_BETWEEN"
+	CodeOutOfRange      = "OUT_OF_RANGE"
+	CodeNotInSet        = "NOT_IN_SET"
+	CodeErrorsClamped   = "ERRORS_CLAMPED"
+	CodePrecondition    = "PRECONDITION"
+	CodePostcondition   = "POSTCONDITION"
+	CodeInvariant       = "INVARIANT"
+	CodeContract        = "CONTRACT"
+	CodePanic           = "PANIC"
+	CodeAborted         = "ABORTED"
+	CodeInvalidIP       = "INVALID_IP"
+	CodeInvalidCIDR     = "INVALID_CIDR"
+	CodeInvalidHostname = "INVALID_HOSTNAME"
+	CodeInvalidPort     = "INVALID_PORT"
+	CodeInvalidHostPort = "INVALID_HOST_PORT"
+	CodeInvalidURL      = "INVALID_URL"
+	CodeInvalidFormat   = "INVALID_FORMAT"
+	CodePatternMismatch = "PATTERN_MISMATCH"
+	CodeDuplicate       = "DUPLICATE"
+	CodeNotSorted       = "NOT_SORTED"
+	CodeMissingKey      = "MISSING_KEY"
+	CodeSign            = "SIGN"
+	CodeNotMultiple     = "NOT_MULTIPLE"
+	CodeNotPowerOfTwo   = "NOT_POWER_OF_TWO"
+	CodeCrossField      = "CROSS_FIELD"
+	CodePredicate       = "PREDICATE"
+	CodeTimeRange       = "TIME_RANGE"
+	CodeAdjusted        = "ADJUSTED"
+)
+
+// CodedError exposes a stable code for keying translations and metrics.
+type CodedError interface {
+	error
+	Code() string
+}
+
+func (e NotNilError) Code() string        { return CodeNotNil }
+func (e NonZeroError) Code() string       { return CodeNonZero }
+func (e NonEmptyError) Code() string      { return CodeNonEmpty }
+func (e LenAtLeastError) Code() string    { return CodeLenAtLeast }
+func (e LenAtMostError) Code() string     { return CodeLenAtMost }
+func (e LenBetweenError) Code() string    { return CodeLenBetween }
+func (e OutOfRangeError[T]) Code() string { return CodeOutOfRange }
+func (e NotInSetError) Code() string      { return CodeNotInSet }
+func (e ErrorsClampedError) Code() string { return CodeErrorsClamped }
+func (e PanicError) Code() string         { return CodePanic }
+
+func (e ContractError) Code() string {
+	switch e.Kind {
+	case ErrPrecondition:
+		return CodePrecondition
+	case ErrPostcondition:
+		return CodePostcondition
+	case ErrInvariant:
+		return CodeInvariant
+	default:
+		return CodeContract
+	}
+}
+
+// CodeOf returns the code of the first CodedError in err's tree, or "" if none.
+// Wrappers (WithPrefix, Redacted, fmt.Errorf %w) are looked through.
+func CodeOf(err error) string {
+	if ce, ok := err.(CodedError); ok {
+		return ce.Code()
+	}
+	var ce CodedError
+	if errors.As(err, &ce) {
+		return ce.Code()
+	}
+	return ""
+}
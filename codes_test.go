@@ -0,0 +1,77 @@
+package sanity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestCodes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Every typed validator error has a code",
+			function: func() interface{} {
+				return []string{
+					sanity.CodeOf(sanity.NotNilPtr[int]("p", nil)),
+					sanity.CodeOf(sanity.NonZero("n", 0)),
+					sanity.CodeOf(sanity.NonEmpty("s", "")),
+					sanity.CodeOf(sanity.StrLenAtLeast("s", "", 1)),
+					sanity.CodeOf(sanity.InRangeNum("n", 5, 0, 1)),
+					sanity.CodeOf(sanity.InSet("m", "x", map[string]struct{}{})),
+				}
+			},
+			expected: []string{"NOT_NIL", "NON_ZERO", "NON_EMPTY", "LEN_AT_LEAST", "OUT_OF_RANGE", "NOT_IN_SET"},
+		},
+		{
+			name: "Contract, panic and clamp codes",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(1))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonEmpty("b", ""))
+				members := sanity.GroupAsSlice(g.Err(), nil)
+				return []string{
+					sanity.CodeOf(sanity.Precondition(false, nil)),
+					sanity.CodeOf(sanity.Postcondition(false, nil)),
+					sanity.CodeOf(sanity.ContractError{Kind: sanity.ErrInvariant}),
+					sanity.CodeOf(sanity.CatchPanic(func() error { panic("boom") })),
+					sanity.CodeOf(members[len(members)-1]),
+				}
+			},
+			expected: []string{"PRECONDITION", "POSTCONDITION", "INVARIANT", "PANIC", "ERRORS_CLAMPED"},
+		},
+		{
+			name: "CodeOf looks through wrappers",
+			function: func() interface{} {
+				err := sanity.NonZero("port", 0)
+				return []string{
+					sanity.CodeOf(sanity.WithPrefix("srv", err)),
+					sanity.CodeOf(sanity.Redacted(err)),
+					sanity.CodeOf(fmt.Errorf("load: %w", err)),
+				}
+			},
+			expected: []string{"NON_ZERO", "NON_ZERO", "NON_ZERO"},
+		},
+		{
+			name: "CodeOf is empty for nil and foreign errors",
+			function: func() interface{} {
+				return []string{sanity.CodeOf(nil), sanity.CodeOf(errors.New("x"))}
+			},
+			expected: []string{"", ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// When returns check if cond is true, and nil otherwise; Guard.Run and
+// AddCheck skip nil checks without counting them, so
+//
+//	g.Run(sanity.When(cfg.TLS.Enabled, func() error { return sanity.NonBlank("tls.cert", cfg.TLS.Cert) }))
+//
+// validates the TLS fields only when TLS is on.
+func When(cond bool, check Check) Check {
+	if !cond {
+		return nil
+	}
+	return check
+}
+
+// Unless is When(!cond, check).
+func Unless(cond bool, check Check) Check {
+	return When(!cond, check)
+}
+
+// All returns a Check that runs checks in order and fails with the first
+// error; nil checks are skipped.
+func All(checks ...Check) Check {
+	return func() error {
+		for _, c := range checks {
+			if c == nil {
+				continue
+			}
+			if err := c(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Any returns a Check that passes as soon as one of checks passes, and
+// otherwise fails with the aggregate of every error ("a valid URL or a file
+// path"). nil checks are skipped; if all are nil it passes.
+func Any(checks ...Check) Check {
+	return func() error {
+		g := NewGuard(WithMaxErrors(0))
+		for _, c := range checks {
+			if c == nil {
+				continue
+			}
+			err := c()
+			if err == nil {
+				return nil
+			}
+			g.Merge(err)
+		}
+		return g.Err()
+	}
+}
+
+// ErrNegated is returned by a Not check whose inner check passed.
+var ErrNegated = errors.New("sanity:negated")
+
+// Not returns a Check that passes when check fails and fails with ErrNegated
+// when it passes. A nil check counts as passing.
+func Not(check Check) Check {
+	return func() error {
+		if check != nil && check() != nil {
+			return nil
+		}
+		return ErrNegated
+	}
+}
+
+// ErrPredicate is the category sentinel of PredicateError.
+var ErrPredicate = errors.New("sanity:predicate")
+
+// PredicateError reports a value rejected by Pred. Rule is the caller's code
+// for the rule, returned by Code() (CodePredicate if empty).
+type PredicateError struct {
+	Field string
+	Rule  string
+	Got   any
+}
+
+func (e PredicateError) Unwrap() error     { return ErrPredicate }
+func (e PredicateError) FieldName() string { return e.Field }
+func (e PredicateError) Error() string     { return e.FieldName() + ": " + e.message(redacting()) }
+func (e PredicateError) gotValue() any     { return e.Got }
+func (e PredicateError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e PredicateError) Code() string {
+	if e.Rule == "" {
+		return CodePredicate
+	}
+	return e.Rule
+}
+
+func (e PredicateError) message(redact bool) string {
+	if redact {
+		return "is invalid (" + e.Code() + ")"
+	}
+	return fmt.Sprintf("is invalid (%s), got %v", e.Code(), e.Got)
+}
+
+// Pred lifts a one-off rule into the error taxonomy: it returns a
+// PredicateError carrying code when pred(v) is false.
+//
+//	sanity.Pred("port", cfg.Port, portFree, "PORT_IN_USE")
+func Pred[T any](name string, v T, pred func(T) bool, code string) error {
+	if pred(v) {
+		return nil
+	}
+	return PredicateError{Field: name, Rule: code, Got: v}
+}
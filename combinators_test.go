@@ -0,0 +1,98 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestCombinators(t *testing.T) {
+	failing := func() error { return sanity.NonEmpty("cert", "") }
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "When runs the check only if cond holds",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Run(sanity.When(false, failing), sanity.When(true, failing))
+				return []interface{}{g.Stats().Checks, g.Stats().Failures}
+			},
+			expected: []interface{}{1, 1},
+		},
+		{
+			name: "Unless is the inverse",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Run(sanity.Unless(true, failing), sanity.Unless(false, failing))
+				return []interface{}{g.Stats().Checks, fieldOf(g.Err())}
+			},
+			expected: []interface{}{1, "cert"},
+		},
+		{
+			name: "All fails with the first error",
+			function: func() interface{} {
+				calls := 0
+				second := func() error { calls++; return nil }
+				err := sanity.All(nil, failing, second)()
+				skipped := calls == 0
+				return []interface{}{fieldOf(err), skipped, sanity.All(second)() == nil}
+			},
+			expected: []interface{}{"cert", true, true},
+		},
+		{
+			name: "Any passes if one passes",
+			function: func() interface{} {
+				url := func() error { return sanity.ValidURL("src", "./data.csv") }
+				path := func() error { return nil }
+				return []bool{sanity.Any(url, path)() == nil, sanity.Any()() == nil, sanity.Any(nil)() == nil}
+			},
+			expected: []bool{true, true, true},
+		},
+		{
+			name: "Any aggregates when all fail",
+			function: func() interface{} {
+				url := func() error { return sanity.ValidURL("src", "x") }
+				ip := func() error { return sanity.ValidIP("src", "x") }
+				n, _ := sanity.GroupLen(sanity.Any(url, ip, failing)())
+				return n
+			},
+			expected: 3,
+		},
+		{
+			name: "Not inverts a check",
+			function: func() interface{} {
+				ok := func() error { return nil }
+				return []interface{}{sanity.Not(failing)() == nil, sanity.Not(ok)(), sanity.Not(nil)()}
+			},
+			expected: []interface{}{true, sanity.ErrNegated, sanity.ErrNegated},
+		},
+		{
+			name: "Pred carries field, code and sentinel",
+			function: func() interface{} {
+				even := func(n int) bool { return n%2 == 0 }
+				err := sanity.Pred("shards", 3, even, "EVEN")
+				verbose := err.Error()
+				return []interface{}{
+					sanity.Pred("shards", 4, even, "EVEN") == nil,
+					errors.Is(err, sanity.ErrPredicate), sanity.CodeOf(err), fieldOf(err),
+					verbose == "shards: is invalid (EVEN), got 3" || sanity.RedactBuild,
+					sanity.Redacted(err).Error(),
+					sanity.CodeOf(sanity.Pred("x", 1, even, "")),
+				}
+			},
+			expected: []interface{}{true, true, "EVEN", "shards", true, "shards: is invalid (EVEN)", sanity.CodePredicate},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package sanity
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// Contract category sentinels (for errors.Is).
+var (
+	ErrPrecondition  = errors.New("sanity:precondition")
+	ErrPostcondition = errors.New("sanity:postcondition")
+	ErrInvariant     = errors.New("sanity:invariant")
+)
+
+// ContractError reports a failed precondition, postcondition or invariant.
+// It matches its Kind sentinel via errors.Is and unwraps to Err.
+type ContractError struct {
+	Kind error // ErrPrecondition, ErrPostcondition or ErrInvariant
+	Err  error // optional detail; may be nil
+}
+
+func (e ContractError) Error() string {
+	msg := "contract violated"
+	switch e.Kind {
+	case ErrPrecondition:
+		msg = "precondition failed"
+	case ErrPostcondition:
+		msg = "postcondition failed"
+	case ErrInvariant:
+		msg = "invariant violated"
+	}
+	if e.Err != nil {
+		return msg + ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e ContractError) Is(target error) bool { return target != nil && target == e.Kind }
+func (e ContractError) Unwrap() error        { return e.Err }
+
+// InvariantMode selects how Invariant reports a violation.
+type InvariantMode int32
+
+const (
+	InvariantReturn InvariantMode = iota // return a ContractError (default)
+	InvariantPanic                       // panic with a ContractError
+)
+
+var invariantMode atomic.Int32
+
+// SetInvariantMode sets the process-wide behavior of Invariant.
+func SetInvariantMode(m InvariantMode) { invariantMode.Store(int32(m)) }
+
+// CurrentInvariantMode returns the process-wide behavior of Invariant.
+func CurrentInvariantMode() InvariantMode { return InvariantMode(invariantMode.Load()) }
+
+// Precondition returns nil if cond holds, else a ContractError{ErrPrecondition, err}.
+func Precondition(cond bool, err error) error {
+	if cond {
+		return nil
+	}
+	return ContractError{Kind: ErrPrecondition, Err: err}
+}
+
+// Postcondition returns nil if cond holds, else a ContractError{ErrPostcondition, err}.
+func Postcondition(cond bool, err error) error {
+	if cond {
+		return nil
+	}
+	return ContractError{Kind: ErrPostcondition, Err: err}
+}
+
+// Invariant returns nil if cond holds. Otherwise it returns, or panics with
+// (see SetInvariantMode), a ContractError{ErrInvariant, err}.
+func Invariant(cond bool, err error) error {
+	if cond {
+		return nil
+	}
+	ce := ContractError{Kind: ErrInvariant, Err: err}
+	if CurrentInvariantMode() == InvariantPanic {
+		panic(ce)
+	}
+	return ce
+}
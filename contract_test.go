@@ -0,0 +1,88 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestContracts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Precondition holds -> nil",
+			function: func() interface{} {
+				return sanity.Precondition(true, errors.New("x")) == nil
+			},
+			expected: true,
+		},
+		{
+			name: "Precondition fails -> category and detail",
+			function: func() interface{} {
+				err := sanity.Precondition(false, sanity.NonZero("n", 0))
+				return []bool{
+					errors.Is(err, sanity.ErrPrecondition),
+					errors.Is(err, sanity.ErrNonZero),
+					errors.Is(err, sanity.ErrPostcondition),
+				}
+			},
+			expected: []bool{true, true, false},
+		},
+		{
+			name: "Postcondition message",
+			function: func() interface{} {
+				return sanity.Postcondition(false, errors.New("len mismatch")).Error()
+			},
+			expected: "postcondition failed: len mismatch",
+		},
+		{
+			name: "Postcondition nil detail message",
+			function: func() interface{} {
+				return sanity.Postcondition(false, nil).Error()
+			},
+			expected: "postcondition failed",
+		},
+		{
+			name: "Invariant returns by default and feeds a Guard",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.Check(sanity.Invariant(false, nil))
+				return errors.Is(g.Err(), sanity.ErrInvariant)
+			},
+			expected: true,
+		},
+		{
+			name: "Invariant panics in InvariantPanic mode",
+			function: func() interface{} {
+				sanity.SetInvariantMode(sanity.InvariantPanic)
+				defer sanity.SetInvariantMode(sanity.InvariantReturn)
+				r := recovered(func() { _ = sanity.Invariant(false, nil) })
+				err, ok := r.(error)
+				return ok && errors.Is(err, sanity.ErrInvariant)
+			},
+			expected: true,
+		},
+		{
+			name: "Invariant holds in panic mode -> nil",
+			function: func() interface{} {
+				sanity.SetInvariantMode(sanity.InvariantPanic)
+				defer sanity.SetInvariantMode(sanity.InvariantReturn)
+				return sanity.Invariant(true, nil) == nil
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
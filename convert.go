@@ -0,0 +1,95 @@
+package sanity
+
+import (
+	"math"
+	"unsafe"
+)
+
+// InRangeConvert converts v to T if it fits, and otherwise returns an
+// OutOfRangeError[U] whose bounds are T's range (as far as U can express it):
+//
+//	n, err := sanity.InRangeConvert[int32]("port", int64(v))
+//
+// Integers must lie within T's bounds; floats converted to an integer type
+// must lie within them too (NaN never does) and are then truncated toward
+// zero as by a Go conversion. Integers always fit a float type, and so do
+// floats except float64 values beyond ±MaxFloat32 converted to float32.
+func InRangeConvert[T, U Numeric](name string, v U) (T, error) {
+	if fits[T](v) {
+		return T(v), nil
+	}
+	min, max := convertBounds[T, U]()
+	return 0, OutOfRangeError[U]{Field: name, Min: min, Max: max, Got: v}
+}
+
+// SafeIntFromUint64 converts v to int, rejecting values above math.MaxInt.
+func SafeIntFromUint64(name string, v uint64) (int, error) {
+	return InRangeConvert[int](name, v)
+}
+
+// SafeUintFromInt converts v to uint, rejecting negative values.
+func SafeUintFromInt(name string, v int) (uint, error) {
+	return InRangeConvert[uint](name, v)
+}
+
+// numInfo describes a Numeric type.
+type numInfo struct {
+	float, signed bool
+	bits          int
+}
+
+func infoOf[T Numeric]() numInfo {
+	var zero T
+	var one T = 1
+	return numInfo{float: one/2 != zero, signed: zero-one < zero, bits: int(unsafe.Sizeof(zero)) * 8}
+}
+
+// intRange returns the bounds of an integer type.
+func (n numInfo) intRange() (int64, uint64) {
+	if n.signed {
+		return -1 << (n.bits - 1), 1<<(n.bits-1) - 1
+	}
+	return 0, math.MaxUint64 >> (64 - n.bits)
+}
+
+func fits[T, U Numeric](v U) bool {
+	t, u := infoOf[T](), infoOf[U]()
+	lo, hi := t.intRange()
+	switch {
+	case u.float:
+		f := float64(v)
+		if t.float {
+			return t.bits == 64 || math.IsNaN(f) || math.IsInf(f, 0) || math.Abs(f) <= math.MaxFloat32
+		}
+		// lo and hi+1 are powers of two (or zero), so the comparisons are exact.
+		f = math.Trunc(f)
+		return f >= float64(lo) && f < math.Ldexp(1, t.bits-btoi(t.signed))
+	case t.float:
+		return true
+	case u.signed && int64(v) < 0:
+		return int64(v) >= lo
+	default:
+		return uint64(v) <= hi
+	}
+}
+
+func convertBounds[T, U Numeric]() (U, U) {
+	t, u := infoOf[T](), infoOf[U]()
+	if t.float { // float64 to float32
+		f := math.MaxFloat32
+		return U(-f), U(f)
+	}
+	lo, hi := t.intRange()
+	if u.float {
+		return U(lo), U(hi)
+	}
+	ulo, uhi := u.intRange()
+	return U(max(lo, ulo)), U(min(hi, uhi))
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+package sanity_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestInRangeConvert(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Values that fit convert",
+			function: func() interface{} {
+				a, e1 := sanity.InRangeConvert[int8]("a", 127)
+				b, e2 := sanity.InRangeConvert[uint16]("b", int64(65535))
+				c, e3 := sanity.InRangeConvert[int64]("c", uint64(math.MaxInt64))
+				d, e4 := sanity.InRangeConvert[int32]("d", -2147483648.9)
+				f, e5 := sanity.InRangeConvert[float32]("f", uint64(math.MaxUint64))
+				return []interface{}{a, b, c, d, f > 0, errors.Join(e1, e2, e3, e4, e5)}
+			},
+			expected: []interface{}{int8(127), uint16(65535), int64(math.MaxInt64), int32(math.MinInt32), true, nil},
+		},
+		{
+			name: "Narrowing reports the destination bounds in the source type",
+			function: func() interface{} {
+				n, err := sanity.InRangeConvert[int8]("level", 300)
+				var oe sanity.OutOfRangeError[int]
+				return []interface{}{n, errors.As(err, &oe), oe.Min, oe.Max, oe.Got}
+			},
+			expected: []interface{}{int8(0), true, -128, 127, 300},
+		},
+		{
+			name: "Bounds clipped to what the source type can express",
+			function: func() interface{} {
+				_, err := sanity.InRangeConvert[int64]("n", uint64(math.MaxUint64))
+				var oe sanity.OutOfRangeError[uint64]
+				errors.As(err, &oe)
+				return []uint64{oe.Min, oe.Max}
+			},
+			expected: []uint64{0, math.MaxInt64},
+		},
+		{
+			name: "Sign, NaN, and float edges",
+			function: func() interface{} {
+				_, neg := sanity.InRangeConvert[uint32]("a", int32(-1))
+				_, nan := sanity.InRangeConvert[int]("b", math.NaN())
+				_, top := sanity.InRangeConvert[int64]("c", 9223372036854775807.0) // rounds to 2^63
+				_, big := sanity.InRangeConvert[float32]("d", math.MaxFloat64)
+				_, inf := sanity.InRangeConvert[float32]("e", math.Inf(1))
+				return []bool{
+					errors.Is(neg, sanity.ErrOutOfRange),
+					errors.Is(nan, sanity.ErrOutOfRange),
+					errors.Is(top, sanity.ErrOutOfRange),
+					errors.Is(big, sanity.ErrOutOfRange),
+					inf == nil,
+				}
+			},
+			expected: []bool{true, true, true, true, true},
+		},
+		{
+			name: "SafeIntFromUint64 and SafeUintFromInt",
+			function: func() interface{} {
+				a, e1 := sanity.SafeIntFromUint64("a", 42)
+				_, e2 := sanity.SafeIntFromUint64("b", math.MaxUint64)
+				c, e3 := sanity.SafeUintFromInt("c", 7)
+				_, e4 := sanity.SafeUintFromInt("d", -1)
+				return []interface{}{a, e1 == nil, e2 != nil, c, e3 == nil, fieldOf(e4)}
+			},
+			expected: []interface{}{42, true, true, uint(7), true, "d"},
+		},
+		{
+			name: "Message shows the destination range",
+			function: func() interface{} {
+				_, err := sanity.InRangeConvert[uint8]("b", -1)
+				return err.Error() == "b: must be in [0,255], got -1" || sanity.RedactBuild
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package sanity
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCrossField is the category sentinel of CrossFieldError.
+var ErrCrossField = errors.New("sanity:cross_field")
+
+// Relations reported in CrossFieldError.Rule.
+const (
+	RuleLess      = "<"
+	RuleLessEq    = "<="
+	RuleGreater   = ">"
+	RuleGreaterEq = ">="
+	RuleTogether  = "together"
+)
+
+// CrossFieldError indicates a constraint between two fields: Field must stand
+// in relation Rule to Other (e.g. min_conns <= max_conns), or, for
+// RuleTogether, Field is unset while Other is set. Got and OtherGot hold the
+// compared values for the ordering rules.
+type CrossFieldError struct {
+	Field, Other  string
+	Rule          string
+	Got, OtherGot any
+}
+
+func (e CrossFieldError) Unwrap() error     { return ErrCrossField }
+func (e CrossFieldError) FieldName() string { return e.Field }
+func (e CrossFieldError) Code() string      { return CodeCrossField }
+func (e CrossFieldError) Error() string     { return e.FieldName() + ": " + e.message(redacting()) }
+
+// withFieldName prefixes Other the same way as Field, so both paths stay
+// comparable after WithPrefix.
+func (e CrossFieldError) withFieldName(name string) error {
+	if prefix, ok := strings.CutSuffix(name, e.Field); ok {
+		e.Other = prefix + e.Other
+	}
+	e.Field = name
+	return e
+}
+
+func (e CrossFieldError) message(redact bool) string {
+	if e.Rule == RuleTogether {
+		return "required together with " + e.Other
+	}
+	if redact {
+		return "must be " + e.Rule + " " + e.Other
+	}
+	return fmt.Sprintf("must be %s %s (got %v vs %v)", e.Rule, e.Other, e.Got, e.OtherGot)
+}
+
+func crossField[T cmp.Ordered](ok bool, nameA string, a T, rule, nameB string, b T) error {
+	if ok {
+		return nil
+	}
+	return CrossFieldError{Field: nameA, Other: nameB, Rule: rule, Got: a, OtherGot: b}
+}
+
+// FieldLess checks a < b.
+func FieldLess[T cmp.Ordered](nameA string, a T, nameB string, b T) error {
+	return crossField(a < b, nameA, a, RuleLess, nameB, b)
+}
+
+// FieldLessEq checks a <= b (e.g. MinConns <= MaxConns).
+func FieldLessEq[T cmp.Ordered](nameA string, a T, nameB string, b T) error {
+	return crossField(a <= b, nameA, a, RuleLessEq, nameB, b)
+}
+
+// FieldGreater checks a > b.
+func FieldGreater[T cmp.Ordered](nameA string, a T, nameB string, b T) error {
+	return crossField(a > b, nameA, a, RuleGreater, nameB, b)
+}
+
+// FieldGreaterEq checks a >= b.
+func FieldGreaterEq[T cmp.Ordered](nameA string, a T, nameB string, b T) error {
+	return crossField(a >= b, nameA, a, RuleGreaterEq, nameB, b)
+}
+
+// Field names a value for RequiredTogether and records whether it is set.
+type Field struct {
+	Name string
+	Set  bool
+}
+
+// FieldOf reports name as set when v is non-zero.
+func FieldOf[T comparable](name string, v T) Field {
+	var zero T
+	return Field{Name: name, Set: v != zero}
+}
+
+// RequiredTogether checks that the fields are either all set or all unset
+// (e.g. TLSCert and TLSKey). It reports the first unset field, relative to
+// the first set one.
+func RequiredTogether(fields ...Field) error {
+	var set, unset string
+	for _, f := range fields {
+		switch {
+		case f.Set && set == "":
+			set = f.Name
+		case !f.Set && unset == "":
+			unset = f.Name
+		}
+	}
+	if set == "" || unset == "" {
+		return nil
+	}
+	return CrossFieldError{Field: unset, Other: set, Rule: RuleTogether}
+}
@@ -0,0 +1,77 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestCrossFieldValidators(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Ordering relations",
+			function: func() interface{} {
+				return []bool{
+					sanity.FieldLess("a", 1, "b", 2) == nil,
+					sanity.FieldLess("a", 2, "b", 2) != nil,
+					sanity.FieldLessEq("a", 2, "b", 2) == nil,
+					sanity.FieldGreater("a", "b", "b", "a") == nil,
+					sanity.FieldGreaterEq("a", 1.5, "b", 2.0) != nil,
+				}
+			},
+			expected: []bool{true, true, true, true, true},
+		},
+		{
+			name: "Error details",
+			function: func() interface{} {
+				err := sanity.FieldLessEq("min_conns", 10, "max_conns", 5)
+				verbose := err.Error()
+				return []interface{}{
+					err, errors.Is(err, sanity.ErrCrossField), sanity.CodeOf(err),
+					verbose == "min_conns: must be <= max_conns (got 10 vs 5)" || sanity.RedactBuild,
+					sanity.Redacted(err).Error(),
+				}
+			},
+			expected: []interface{}{
+				sanity.CrossFieldError{Field: "min_conns", Other: "max_conns", Rule: sanity.RuleLessEq, Got: 10, OtherGot: 5},
+				true, sanity.CodeCrossField, true, "min_conns: must be <= max_conns",
+			},
+		},
+		{
+			name: "Prefix applies to both fields",
+			function: func() interface{} {
+				err := sanity.WithPrefix("db.pool", sanity.FieldLess("min", 3, "max", 1))
+				var ce sanity.CrossFieldError
+				errors.As(err, &ce)
+				return []string{ce.Field, ce.Other}
+			},
+			expected: []string{"db.pool.min", "db.pool.max"},
+		},
+		{
+			name: "RequiredTogether",
+			function: func() interface{} {
+				cert, key := "cert.pem", ""
+				err := sanity.RequiredTogether(sanity.FieldOf("tls_cert", cert), sanity.FieldOf("tls_key", key))
+				return []interface{}{
+					sanity.RequiredTogether(sanity.FieldOf("a", ""), sanity.FieldOf("b", 0)) == nil,
+					sanity.RequiredTogether(sanity.FieldOf("a", "x"), sanity.Field{Name: "b", Set: true}) == nil,
+					err.Error(),
+				}
+			},
+			expected: []interface{}{true, true, "tls_key: required together with tls_cert"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
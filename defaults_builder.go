@@ -0,0 +1,62 @@
+package sanity
+
+// Defaults is a fluent alternative to a block of SetIfZero/Clamp calls.
+// Go methods cannot take their own type parameters, so the builder accepts
+// DefaultStep values made by generic constructors; each step stays type-checked:
+//
+//	sanity.DefaultsFor(&cfg).
+//		Field(sanity.Default(&cfg.Port, 8080), sanity.Default(&cfg.Timeout, 5*time.Second)).
+//		Field(sanity.ClampTo(&cfg.Workers, 1, 64)).
+//		Apply()
+
+// DefaultStep is one normalization applied by Defaults.Apply.
+type DefaultStep func()
+
+// Default sets *p to def if *p is zero (SetIfZero).
+func Default[V comparable](p *V, def V) DefaultStep {
+	return func() { SetIfZero(p, def) }
+}
+
+// DefaultPtr sets *p to def if *p is nil (SetIfNil).
+func DefaultPtr[V any](p **V, def *V) DefaultStep {
+	return func() { SetIfNil(p, def) }
+}
+
+// ClampTo clamps *p into [min,max] (Clamp).
+func ClampTo[V Numeric](p *V, min, max V) DefaultStep {
+	return func() { Clamp(p, min, max) }
+}
+
+// DefaultThenClamp sets *p to def if zero, then clamps it (SetIfZeroThenClamp).
+func DefaultThenClamp[V Numeric](p *V, def, min, max V) DefaultStep {
+	return func() { SetIfZeroThenClamp(p, def, min, max) }
+}
+
+// Defaults collects normalization steps for a config value.
+type Defaults[T any] struct {
+	target *T
+	steps  []DefaultStep
+}
+
+// DefaultsFor starts a Defaults builder for target.
+func DefaultsFor[T any](target *T) *Defaults[T] {
+	return &Defaults[T]{target: target}
+}
+
+// Field appends steps; nil steps are ignored.
+func (d *Defaults[T]) Field(steps ...DefaultStep) *Defaults[T] {
+	for _, s := range steps {
+		if s != nil {
+			d.steps = append(d.steps, s)
+		}
+	}
+	return d
+}
+
+// Apply runs the steps in order and returns the target.
+func (d *Defaults[T]) Apply() *T {
+	for _, s := range d.steps {
+		s()
+	}
+	return d.target
+}
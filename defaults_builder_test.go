@@ -0,0 +1,88 @@
+package sanity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sessaidi/sanity"
+	"github.com/stretchr/testify/assert"
+)
+
+type builderCfg struct {
+	Port    int
+	Timeout time.Duration
+	Workers int
+	Retries int
+	Max     *int
+}
+
+func TestDefaultsBuilder(t *testing.T) {
+	seven := 7
+	testCases := []struct {
+		name     string
+		fn       func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "zero fields get defaults, clamp applies",
+			fn: func() interface{} {
+				var cfg builderCfg
+				cfg.Workers = 500
+				sanity.DefaultsFor(&cfg).
+					Field(sanity.Default(&cfg.Port, 8080), sanity.Default(&cfg.Timeout, 5*time.Second)).
+					Field(sanity.ClampTo(&cfg.Workers, 1, 64)).
+					Field(sanity.DefaultPtr(&cfg.Max, &seven)).
+					Apply()
+				return cfg
+			},
+			expected: builderCfg{Port: 8080, Timeout: 5 * time.Second, Workers: 64, Max: &seven},
+		},
+		{
+			name: "set fields are kept",
+			fn: func() interface{} {
+				cfg := builderCfg{Port: 9000, Timeout: time.Second, Workers: 8}
+				sanity.DefaultsFor(&cfg).
+					Field(sanity.Default(&cfg.Port, 8080), sanity.Default(&cfg.Timeout, 5*time.Second)).
+					Field(sanity.ClampTo(&cfg.Workers, 1, 64)).
+					Apply()
+				return cfg
+			},
+			expected: builderCfg{Port: 9000, Timeout: time.Second, Workers: 8},
+		},
+		{
+			name: "steps run in order",
+			fn: func() interface{} {
+				var cfg builderCfg
+				sanity.DefaultsFor(&cfg).
+					Field(sanity.ClampTo(&cfg.Retries, 1, 5)).
+					Field(sanity.Default(&cfg.Retries, 3)).
+					Apply()
+				return cfg.Retries
+			},
+			expected: 1,
+		},
+		{
+			name: "DefaultThenClamp",
+			fn: func() interface{} {
+				var cfg builderCfg
+				sanity.DefaultsFor(&cfg).Field(sanity.DefaultThenClamp(&cfg.Workers, 100, 1, 64)).Apply()
+				return cfg.Workers
+			},
+			expected: 64,
+		},
+		{
+			name: "nil steps ignored; Apply returns target",
+			fn: func() interface{} {
+				cfg := &builderCfg{}
+				return sanity.DefaultsFor(cfg).Field(nil).Apply() == cfg
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.fn())
+		})
+	}
+}
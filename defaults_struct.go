@@ -0,0 +1,143 @@
+package sanity
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+var errUnsupportedType = errors.New("unsupported type")
+
+// ApplyDefaults walks a pointer to struct and fills fields from their
+// `default:"..."` tags with SetIfZero/SetIfNil semantics: a field is only
+// written when it is zero (or a nil pointer, which is then allocated).
+//
+// Supported field types: strings, bools, integers, floats, time.Duration
+// ("3s"), types implementing encoding.TextUnmarshaler, pointers to any of
+// these, and slices of scalars ("a,b,c"). Nested structs are walked
+// recursively; a nil pointer to struct is allocated only when it carries a
+// default tag (conventionally `default:"{}"`).
+func ApplyDefaults(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("sanity: ApplyDefaults(%T): want non-nil pointer to struct: %w", v, ErrNotStruct)
+	}
+	return applyDefaultsStruct(rv.Elem())
+}
+
+func applyDefaultsStruct(rv reflect.Value) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		def, hasDef := sf.Tag.Lookup("default")
+		if err := applyDefaultField(fv, def, hasDef); err != nil {
+			return fmt.Errorf("sanity: %s.%s: %w", t.Name(), sf.Name, err)
+		}
+	}
+	return nil
+}
+
+func applyDefaultField(fv reflect.Value, def string, hasDef bool) error {
+	ft := fv.Type()
+	switch {
+	case ft.Kind() == reflect.Pointer && ft.Elem().Kind() == reflect.Struct && !ft.Implements(textUnmarshalerType):
+		if fv.IsNil() {
+			if !hasDef {
+				return nil
+			}
+			fv.Set(reflect.New(ft.Elem()))
+		}
+		return applyDefaultsStruct(fv.Elem())
+	case ft.Kind() == reflect.Struct && !reflect.PointerTo(ft).Implements(textUnmarshalerType):
+		return applyDefaultsStruct(fv)
+	case !hasDef:
+		return nil
+	case ft.Kind() == reflect.Pointer:
+		if !fv.IsNil() {
+			return nil
+		}
+		p := reflect.New(ft.Elem())
+		if err := setFromString(p.Elem(), def); err != nil {
+			return err
+		}
+		fv.Set(p)
+		return nil
+	case !fv.IsZero():
+		return nil
+	default:
+		return setFromString(fv, def)
+	}
+}
+
+// setFromString parses a default tag s into fv according to fv's type.
+func setFromString(fv reflect.Value, s string) error {
+	return wrapBadTag(s, parseInto(fv, s))
+}
+
+// parseInto parses s into fv according to fv's type.
+func parseInto(fv reflect.Value, s string) error {
+	if fv.CanAddr() {
+		if u, ok := fv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return u.UnmarshalText([]byte(s))
+		}
+	}
+	var err error
+	switch k := fv.Kind(); {
+	case fv.Type() == durationType:
+		var d time.Duration
+		if d, err = time.ParseDuration(s); err == nil {
+			fv.SetInt(int64(d))
+		}
+	case k == reflect.String:
+		fv.SetString(s)
+	case k == reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); err == nil {
+			fv.SetBool(b)
+		}
+	case isIntKind(k):
+		var n int64
+		if n, err = strconv.ParseInt(s, 0, fv.Type().Bits()); err == nil {
+			fv.SetInt(n)
+		}
+	case isUintKind(k):
+		var n uint64
+		if n, err = strconv.ParseUint(s, 0, fv.Type().Bits()); err == nil {
+			fv.SetUint(n)
+		}
+	case k == reflect.Float32 || k == reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(s, fv.Type().Bits()); err == nil {
+			fv.SetFloat(f)
+		}
+	case k == reflect.Slice:
+		parts := strings.Split(s, ",")
+		out := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err = parseInto(out.Index(i), strings.TrimSpace(p)); err != nil {
+				return err
+			}
+		}
+		fv.Set(out)
+	default:
+		err = fmt.Errorf("%w %s", errUnsupportedType, fv.Type())
+	}
+	return err
+}
+
+func wrapBadTag(s string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("default %q: %v: %w", s, err, ErrBadTag)
+}
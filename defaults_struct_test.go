@@ -0,0 +1,96 @@
+package sanity_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+type dbDefaults struct {
+	Host    string        `default:"localhost"`
+	MaxIdle *int          `default:"4"`
+	Timeout time.Duration `default:"3s"`
+}
+
+type appDefaults struct {
+	Port    int           `default:"8080"`
+	Debug   *bool         `default:"true"`
+	Ratio   float32       `default:"0.25"`
+	Workers uint8         `default:"0x10"`
+	Tags    []string      `default:"a, b"`
+	Bind    net.IP        `default:"127.0.0.1"`
+	Retry   time.Duration `default:"250ms"`
+	DB      dbDefaults
+	Cache   *dbDefaults `default:"{}"`
+	Extra   *dbDefaults
+	Name    string
+}
+
+func TestApplyDefaults(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Zero fields filled from tags, nested included",
+			function: func() interface{} {
+				var c appDefaults
+				err := sanity.ApplyDefaults(&c)
+				return []interface{}{
+					err, c.Port, *c.Debug, c.Ratio, c.Workers, c.Tags, c.Bind.String(), c.Retry,
+					c.DB.Host, *c.DB.MaxIdle, c.DB.Timeout, c.Cache.Host, c.Extra == nil, c.Name,
+				}
+			},
+			expected: []interface{}{
+				nil, 8080, true, float32(0.25), uint8(16), []string{"a", "b"}, "127.0.0.1", 250 * time.Millisecond,
+				"localhost", 4, 3 * time.Second, "localhost", true, "",
+			},
+		},
+		{
+			name: "Non-zero values and non-nil pointers are kept",
+			function: func() interface{} {
+				off := false
+				c := appDefaults{Port: 9000, Debug: &off, Extra: &dbDefaults{Host: "db"}}
+				_ = sanity.ApplyDefaults(&c)
+				return []interface{}{c.Port, *c.Debug, c.Extra.Host, c.Extra.Timeout}
+			},
+			expected: []interface{}{9000, false, "db", 3 * time.Second},
+		},
+		{
+			name: "Non-pointer target -> ErrNotStruct",
+			function: func() interface{} {
+				return errors.Is(sanity.ApplyDefaults(appDefaults{}), sanity.ErrNotStruct)
+			},
+			expected: true,
+		},
+		{
+			name: "Unparsable default -> ErrBadTag",
+			function: func() interface{} {
+				type bad struct {
+					N int `default:"many"`
+				}
+				type unsupported struct {
+					M map[string]int `default:"a"`
+				}
+				return []bool{
+					errors.Is(sanity.ApplyDefaults(&bad{}), sanity.ErrBadTag),
+					errors.Is(sanity.ApplyDefaults(&unsupported{}), sanity.ErrBadTag),
+				}
+			},
+			expected: []bool{true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
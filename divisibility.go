@@ -0,0 +1,62 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDivisibility is the category sentinel of DivisibilityError.
+var ErrDivisibility = errors.New("sanity:divisibility")
+
+// DivisibilityError indicates an integer that is not a multiple of Base, or,
+// with Pow2 set, not a power of two (Base is then 2).
+type DivisibilityError[T Integer] struct {
+	Field string
+	Base  T
+	Pow2  bool
+	Got   T
+}
+
+func (e DivisibilityError[T]) Unwrap() error     { return ErrDivisibility }
+func (e DivisibilityError[T]) FieldName() string { return e.Field }
+func (e DivisibilityError[T]) Error() string     { return e.FieldName() + ": " + e.message(redacting()) }
+func (e DivisibilityError[T]) gotValue() any     { return e.Got }
+func (e DivisibilityError[T]) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e DivisibilityError[T]) Code() string {
+	if e.Pow2 {
+		return CodeNotPowerOfTwo
+	}
+	return CodeNotMultiple
+}
+
+func (e DivisibilityError[T]) message(redact bool) string {
+	want := fmt.Sprintf("a multiple of %v", e.Base)
+	if e.Pow2 {
+		want = "a power of two"
+	}
+	if redact {
+		return "must be " + want
+	}
+	return fmt.Sprintf("must be %s, got %v", want, e.Got)
+}
+
+// MultipleOf checks that v is a multiple of base (buffer sizes, alignment).
+// Zero is a multiple of everything; with base 0 only v == 0 passes.
+func MultipleOf[T Integer](name string, v, base T) error {
+	if v == 0 || (base != 0 && v%base == 0) {
+		return nil
+	}
+	return DivisibilityError[T]{Field: name, Base: base, Got: v}
+}
+
+// PowerOfTwo checks that v is 1, 2, 4, 8, ... (shard counts, ring sizes).
+func PowerOfTwo(name string, v int) error {
+	if v > 0 && v&(v-1) == 0 {
+		return nil
+	}
+	return DivisibilityError[int]{Field: name, Base: 2, Pow2: true, Got: v}
+}
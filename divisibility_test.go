@@ -0,0 +1,69 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestDivisibilityValidators(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "MultipleOf",
+			function: func() interface{} {
+				return []bool{
+					sanity.MultipleOf("buf", 8192, 4096) == nil,
+					sanity.MultipleOf("buf", 0, 4096) == nil,
+					sanity.MultipleOf("buf", -12, 4) == nil,
+					sanity.MultipleOf("buf", uint32(10), 0) != nil,
+					errors.Is(sanity.MultipleOf("buf", 5000, 4096), sanity.ErrDivisibility),
+				}
+			},
+			expected: []bool{true, true, true, true, true},
+		},
+		{
+			name: "MultipleOf error details",
+			function: func() interface{} {
+				err := sanity.MultipleOf("align", int64(6), 4)
+				var de sanity.DivisibilityError[int64]
+				ok := errors.As(err, &de)
+				verbose := err.Error()
+				return []interface{}{
+					ok, de.Base, de.Got, sanity.CodeOf(err),
+					verbose == "align: must be a multiple of 4, got 6" || sanity.RedactBuild,
+					sanity.Redacted(err).Error(),
+				}
+			},
+			expected: []interface{}{true, int64(4), int64(6), sanity.CodeNotMultiple, true, "align: must be a multiple of 4"},
+		},
+		{
+			name: "PowerOfTwo",
+			function: func() interface{} {
+				err := sanity.PowerOfTwo("shards", 12)
+				verbose := err.Error()
+				return []interface{}{
+					sanity.PowerOfTwo("shards", 1) == nil,
+					sanity.PowerOfTwo("shards", 1024) == nil,
+					sanity.PowerOfTwo("shards", 0) != nil,
+					sanity.PowerOfTwo("shards", -8) != nil,
+					errors.Is(err, sanity.ErrDivisibility), sanity.CodeOf(err),
+					verbose == "shards: must be a power of two, got 12" || sanity.RedactBuild,
+				}
+			},
+			expected: []interface{}{true, true, true, true, true, sanity.CodeNotPowerOfTwo, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
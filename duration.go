@@ -1,11 +1,35 @@
 package sanity
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 func ClampDuration(p *time.Duration, min, max time.Duration) {
 	Clamp(p, min, max)
 }
 
+func ClampDurationReport(p *time.Duration, min, max time.Duration) (adjusted bool, old time.Duration) {
+	return ClampReport(p, min, max)
+}
+
 func DefaultDurationClamp(v, def, min, max time.Duration) time.Duration {
 	return DefaultIfClamp(v, def, min, max)
 }
+
+// ParseDurationOr parses s with time.ParseDuration ("30s", "1h30m", "0"),
+// ignoring surrounding whitespace, and returns def if s is blank or invalid.
+func ParseDurationOr(s string, def time.Duration) time.Duration {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return def
+	}
+	return d
+}
+
+// ParseDurationClamp is ParseDurationOr followed by Clamp into [min,max].
+func ParseDurationClamp(s string, def, min, max time.Duration) time.Duration {
+	d := ParseDurationOr(s, def)
+	Clamp(&d, min, max)
+	return d
+}
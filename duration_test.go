@@ -110,6 +110,38 @@ func TestDurationFunctions(t *testing.T) {
 			},
 			expected: 2 * time.Second,
 		},
+		{
+			name: "ClampDurationReport below min -> adjusted",
+			function: func() interface{} {
+				d := 200 * time.Millisecond
+				adjusted, old := sanity.ClampDurationReport(&d, 1*time.Second, 2*time.Second)
+				return [3]interface{}{d, adjusted, old}
+			},
+			expected: [3]interface{}{1 * time.Second, true, 200 * time.Millisecond},
+		},
+		{
+			name: "ParseDurationOr parses and falls back",
+			function: func() interface{} {
+				return [4]time.Duration{
+					sanity.ParseDurationOr(" 30s ", time.Second),
+					sanity.ParseDurationOr("0", time.Second),
+					sanity.ParseDurationOr("", time.Second),
+					sanity.ParseDurationOr("30", time.Second),
+				}
+			},
+			expected: [4]time.Duration{30 * time.Second, 0, time.Second, time.Second},
+		},
+		{
+			name: "ParseDurationClamp clamps parsed and default values",
+			function: func() interface{} {
+				return [3]time.Duration{
+					sanity.ParseDurationClamp("1h", 5*time.Second, time.Second, time.Minute),
+					sanity.ParseDurationClamp("bogus", 5*time.Second, time.Second, time.Minute),
+					sanity.ParseDurationClamp("0", 5*time.Second, time.Second, time.Minute),
+				}
+			},
+			expected: [3]time.Duration{time.Minute, 5 * time.Second, time.Second},
+		},
 	}
 
 	for _, tc := range testCases {
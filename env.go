@@ -0,0 +1,135 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// EnvOr returns the value of the environment variable name, or def if it is
+// unset or blank.
+func EnvOr(name, def string) string {
+	if v := os.Getenv(name); strings.TrimSpace(v) != "" {
+		return v
+	}
+	return def
+}
+
+// EnvIntClamp is ParseIntClamp applied to the environment variable name.
+func EnvIntClamp(name string, def, min, max int) int {
+	return ParseIntClamp(os.Getenv(name), def, min, max)
+}
+
+// EnvDurationClamp is ParseDurationClamp applied to the environment variable name.
+func EnvDurationClamp(name string, def, min, max time.Duration) time.Duration {
+	return ParseDurationClamp(os.Getenv(name), def, min, max)
+}
+
+// EnvBoolOr is ParseBoolOr applied to the environment variable name.
+func EnvBoolOr(name string, def bool) bool {
+	return ParseBoolOr(os.Getenv(name), def)
+}
+
+// RequireEnv reports every unset or blank environment variable among names as
+// a MissingKeyError named after it, aggregated like ValidateStruct, so the
+// result can be passed to Guard.Merge.
+func RequireEnv(names ...string) error {
+	g := NewGuard(WithMaxErrors(0))
+	for _, name := range names {
+		if strings.TrimSpace(os.Getenv(name)) == "" {
+			g.Add(MissingKeyError{Field: name})
+		}
+	}
+	return g.Err()
+}
+
+// ApplyEnv walks a pointer to struct and overwrites fields tagged
+// `env:"NAME"` with the value of that environment variable, parsed like a
+// default tag (see ApplyDefaults). Unset or empty variables leave the field
+// alone; nested structs and non-nil pointers to structs are walked
+// recursively. lookup defaults to os.LookupEnv.
+//
+// Values that do not parse are reported as FormatErrors named after the
+// variable, aggregated like ValidateStruct; the field keeps its value.
+func ApplyEnv(v any, lookup func(string) (string, bool)) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("sanity: ApplyEnv(%T): want non-nil pointer to struct: %w", v, ErrNotStruct)
+	}
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	g := NewGuard(WithMaxErrors(0))
+	if err := applyEnvStruct(&g, rv.Elem(), lookup); err != nil {
+		return err
+	}
+	return g.Err()
+}
+
+func applyEnvStruct(g *Guard, rv reflect.Value, lookup func(string) (string, bool)) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if nested, ok := nestedStruct(rv.Field(i)); ok && !reflect.PointerTo(nested.Type()).Implements(textUnmarshalerType) {
+			if err := applyEnvStruct(g, nested, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+		name := sf.Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		s, ok := lookup(name)
+		if !ok || s == "" {
+			continue
+		}
+		if err := setFromEnv(rv.Field(i), s); err != nil {
+			if errors.Is(err, errUnsupportedType) {
+				return fmt.Errorf("sanity: %s.%s: env %s: %v: %w", t.Name(), sf.Name, name, err, ErrBadTag)
+			}
+			g.Add(FormatError{Field: name, Format: formatOf(sf.Type), Got: s})
+		}
+	}
+	return nil
+}
+
+// setFromEnv parses s into a fresh value of fv's type (allocating through a
+// pointer) and stores it only on success.
+func setFromEnv(fv reflect.Value, s string) error {
+	t := fv.Type()
+	if t.Kind() == reflect.Pointer {
+		p := reflect.New(t.Elem())
+		if err := parseInto(p.Elem(), s); err != nil {
+			return err
+		}
+		fv.Set(p)
+		return nil
+	}
+	p := reflect.New(t)
+	if err := parseInto(p.Elem(), s); err != nil {
+		return err
+	}
+	fv.Set(p.Elem())
+	return nil
+}
+
+// formatOf names the format expected for a field of type t in FormatError.
+func formatOf(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == durationType:
+		return FormatDuration
+	case isIntKind(t.Kind()) || isUintKind(t.Kind()):
+		return FormatInteger
+	}
+	return t.String()
+}
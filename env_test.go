@@ -0,0 +1,160 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+type envDB struct {
+	URL string `env:"DB_URL"`
+}
+
+type envConfig struct {
+	Port    int           `env:"PORT"`
+	Debug   bool          `env:"DEBUG"`
+	Timeout time.Duration `env:"TIMEOUT"`
+	Hosts   []string      `env:"HOSTS"`
+	Limit   *int          `env:"LIMIT"`
+	DB      envDB
+	Name    string
+}
+
+func envOf(m map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := m[name]
+		return v, ok
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Set variables overlay fields, unset and empty ones do not",
+			function: func() interface{} {
+				c := envConfig{Port: 80, Name: "keep", Debug: true}
+				err := sanity.ApplyEnv(&c, envOf(map[string]string{
+					"PORT": "8080", "DEBUG": "", "TIMEOUT": "3s", "HOSTS": "a, b", "LIMIT": "5", "DB_URL": "pg://x", "Name": "x",
+				}))
+				return []interface{}{err == nil, c.Port, c.Debug, c.Timeout, c.Hosts, *c.Limit, c.DB.URL, c.Name}
+			},
+			expected: []interface{}{true, 8080, true, 3 * time.Second, []string{"a", "b"}, 5, "pg://x", "keep"},
+		},
+		{
+			name: "Malformed values aggregated as FormatErrors, fields kept",
+			function: func() interface{} {
+				c := envConfig{Port: 80}
+				err := sanity.ApplyEnv(&c, envOf(map[string]string{"PORT": "eighty", "TIMEOUT": "soon", "LIMIT": "x"}))
+				var fe sanity.FormatError
+				errors.As(err, &fe)
+				return []interface{}{fieldsOf(err), fe.Format, c.Port, c.Limit == nil}
+			},
+			expected: []interface{}{[]string{"PORT", "TIMEOUT", "LIMIT"}, sanity.FormatInteger, 80, true},
+		},
+		{
+			name: "Bad targets and unsupported types",
+			function: func() interface{} {
+				type chanField struct {
+					C chan int `env:"C"`
+				}
+				var n int
+				return []bool{
+					errors.Is(sanity.ApplyEnv(n, nil), sanity.ErrNotStruct),
+					errors.Is(sanity.ApplyEnv(&chanField{}, envOf(map[string]string{"C": "1"})), sanity.ErrBadTag),
+				}
+			},
+			expected: []bool{true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
+
+func TestEnvHelpers(t *testing.T) {
+	t.Setenv("SANITY_TEST_NAME", "api")
+	t.Setenv("SANITY_TEST_BLANK", "  ")
+	t.Setenv("SANITY_TEST_WORKERS", " 500 ")
+	t.Setenv("SANITY_TEST_BAD", "many")
+	t.Setenv("SANITY_TEST_TIMEOUT", "90s")
+	t.Setenv("SANITY_TEST_DEBUG", "T")
+
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "EnvOr",
+			function: func() interface{} {
+				return []string{
+					sanity.EnvOr("SANITY_TEST_NAME", "x"),
+					sanity.EnvOr("SANITY_TEST_BLANK", "x"),
+					sanity.EnvOr("SANITY_TEST_UNSET", "x"),
+				}
+			},
+			expected: []string{"api", "x", "x"},
+		},
+		{
+			name: "EnvIntClamp",
+			function: func() interface{} {
+				return []int{
+					sanity.EnvIntClamp("SANITY_TEST_WORKERS", 4, 1, 64),
+					sanity.EnvIntClamp("SANITY_TEST_BAD", 4, 1, 64),
+					sanity.EnvIntClamp("SANITY_TEST_UNSET", 0, 1, 64),
+				}
+			},
+			expected: []int{64, 4, 1},
+		},
+		{
+			name: "EnvDurationClamp",
+			function: func() interface{} {
+				return []time.Duration{
+					sanity.EnvDurationClamp("SANITY_TEST_TIMEOUT", time.Second, 0, time.Minute),
+					sanity.EnvDurationClamp("SANITY_TEST_BAD", time.Second, 0, time.Minute),
+				}
+			},
+			expected: []time.Duration{time.Minute, time.Second},
+		},
+		{
+			name: "EnvBoolOr",
+			function: func() interface{} {
+				return []bool{
+					sanity.EnvBoolOr("SANITY_TEST_DEBUG", false),
+					sanity.EnvBoolOr("SANITY_TEST_BAD", true),
+					sanity.EnvBoolOr("SANITY_TEST_UNSET", false),
+				}
+			},
+			expected: []bool{true, true, false},
+		},
+		{
+			name: "RequireEnv reports every missing variable",
+			function: func() interface{} {
+				err := sanity.RequireEnv("SANITY_TEST_NAME", "SANITY_TEST_BLANK", "SANITY_TEST_UNSET")
+				return []interface{}{
+					fieldsOf(err),
+					errors.Is(err, sanity.ErrMissingKey),
+					sanity.RequireEnv("SANITY_TEST_NAME") == nil,
+				}
+			},
+			expected: []interface{}{[]string{"SANITY_TEST_BLANK", "SANITY_TEST_UNSET"}, true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -24,6 +24,20 @@ type LenAtLeastError struct {
 	Got   int
 }
 
+// LenAtMostError indicates len(value) > Want.
+type LenAtMostError struct {
+	Field string
+	Want  int
+	Got   int
+}
+
+// LenBetweenError indicates len(value) ∉ [Min,Max] (inclusive).
+type LenBetweenError struct {
+	Field    string
+	Min, Max int
+	Got      int
+}
+
 // OutOfRangeError indicates v ∉ [Min,Max] (inclusive).
 type OutOfRangeError[T any] struct {
 	Field    string
@@ -31,9 +45,17 @@ type OutOfRangeError[T any] struct {
 	Got      T
 }
 
-// NotInSetError indicates v ∉ allowed set.
+// NotInSetError indicates v ∉ allowed set. AllowedValues lists the acceptable
+// values when the validator reports them (OneOf, InSetVerbose, oneof= tags);
+// it is empty for InSet. The list is held behind a pointer so the type stays
+// comparable.
 type NotInSetError struct {
-	Field string
+	Field   string
+	allowed *[]string
+}
+
+func notInSet(name string, allowed []string) NotInSetError {
+	return NotInSetError{Field: name, allowed: &allowed}
 }
 
 // ---- Category sentinels (for errors.Is) ----
@@ -42,6 +64,8 @@ var (
 	ErrNonZero    = errors.New("sanity:non_zero")
 	ErrNonEmpty   = errors.New("sanity:non_empty")
 	ErrLenAtLeast = errors.New("sanity:len_at_least")
+	ErrLenAtMost  = errors.New("sanity:len_at_most")
+	ErrLenBetween = errors.New("sanity:len_between")
 	ErrOutOfRange = errors.New("sanity:out_of_range")
 	ErrNotInSet   = errors.New("sanity:not_in_set")
 )
@@ -62,6 +86,14 @@ type RangeError interface {
 	Value() any
 }
 
+// SetError exposes the acceptable values of a failed membership check.
+// AllowedValues is empty when the validator did not report them (InSet).
+type SetError interface {
+	error
+	FieldName() string
+	AllowedValues() []string
+}
+
 // ---- Unwrap to category sentinels ----
 
 func (e NotNilError) Unwrap() error {
@@ -80,6 +112,14 @@ func (e LenAtLeastError) Unwrap() error {
 	return ErrLenAtLeast
 }
 
+func (e LenAtMostError) Unwrap() error {
+	return ErrLenAtMost
+}
+
+func (e LenBetweenError) Unwrap() error {
+	return ErrLenBetween
+}
+
 func (e NotInSetError) Unwrap() error {
 	return ErrNotInSet
 }
@@ -106,6 +146,14 @@ func (e LenAtLeastError) FieldName() string {
 	return e.Field
 }
 
+func (e LenAtMostError) FieldName() string {
+	return e.Field
+}
+
+func (e LenBetweenError) FieldName() string {
+	return e.Field
+}
+
 func (e NotInSetError) FieldName() string {
 	return e.Field
 }
@@ -116,6 +164,14 @@ func (e OutOfRangeError[T]) FieldName() string {
 
 // ---- Range details ----
 
+func (e LenBetweenError) Bounds() (any, any) {
+	return e.Min, e.Max
+}
+
+func (e LenBetweenError) Value() any {
+	return e.Got
+}
+
 func (e OutOfRangeError[T]) Bounds() (any, any) {
 	return e.Min, e.Max
 }
@@ -123,3 +179,69 @@ func (e OutOfRangeError[T]) Bounds() (any, any) {
 func (e OutOfRangeError[T]) Value() any {
 	return e.Got
 }
+
+// ---- Set details ----
+
+func (e NotInSetError) AllowedValues() []string {
+	if e.allowed == nil {
+		return nil
+	}
+	return *e.allowed
+}
+
+// lenBound is implemented by the one-sided length errors (want vs got).
+type lenBound interface {
+	lenWantGot() (want, got int)
+}
+
+func (e LenAtLeastError) lenWantGot() (int, int) { return e.Want, e.Got }
+func (e LenAtMostError) lenWantGot() (int, int)  { return e.Want, e.Got }
+
+// ---- Field renaming (for path prefixes) ----
+
+// fieldRenamer is implemented by typed errors that can report a different field
+// name; prefixing uses it so callers keep the concrete type via errors.As.
+type fieldRenamer interface {
+	FieldError
+	withFieldName(name string) error
+}
+
+func (e NotNilError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e NonZeroError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e NonEmptyError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e LenAtLeastError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e LenAtMostError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e LenBetweenError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e NotInSetError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e OutOfRangeError[T]) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
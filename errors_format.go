@@ -0,0 +1,106 @@
+package sanity
+
+import (
+	"strconv"
+	"strings"
+)
+
+// WithMessageLimit caps the length of the aggregate's Error() text; members
+// that don't fit are summarized as "(N more)". n <= 0 means unlimited.
+func WithMessageLimit(n int) GuardOption {
+	return func(g *Guard) { g.msgLimit = n }
+}
+
+// Error renders "N errors: a: ...; b: ...", honoring the message limit.
+func (m *multiError) Error() string {
+	var buf [8]string
+	msgs := buf[:0]
+	m.Iter(func(e error) bool {
+		msgs = append(msgs, e.Error())
+		return true
+	})
+	return joinMessages(msgs, m.limit)
+}
+
+// FormatList joins the messages of err's members with sep (for logs); a
+// non-aggregate renders as err.Error(), nil as "".
+func FormatList(err error, sep string) string {
+	if err == nil {
+		return ""
+	}
+	eg, ok := err.(ErrorGroup)
+	if !ok {
+		return err.Error()
+	}
+	var b strings.Builder
+	eg.Iter(func(e error) bool {
+		if b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(e.Error())
+		return true
+	})
+	return b.String()
+}
+
+// groupLike returns an empty aggregate with the same rendering settings as err.
+func groupLike(err error) *multiError {
+	if m, ok := err.(*multiError); ok {
+		return &multiError{limit: m.limit}
+	}
+	return &multiError{}
+}
+
+func joinMessages(msgs []string, limit int) string {
+	const sep = "; "
+	head := strconv.Itoa(len(msgs))
+	if len(msgs) == 1 {
+		head += " error: "
+	} else {
+		head += " errors: "
+	}
+
+	size, k := len(head), 0
+	for i, s := range msgs {
+		add := len(s)
+		if i > 0 {
+			add += len(sep)
+		}
+		if limit > 0 && size+add > limit {
+			break
+		}
+		size += add
+		k++
+	}
+	var tail string
+	if k < len(msgs) {
+		tail = "(" + strconv.Itoa(len(msgs)-k) + " more)"
+		if k > 0 {
+			tail = sep + tail
+		}
+		// Make room for the summary within the limit.
+		for k > 0 && size+len(tail) > limit {
+			size -= len(msgs[k-1])
+			if k > 1 {
+				size -= len(sep)
+			}
+			k--
+			tail = "(" + strconv.Itoa(len(msgs)-k) + " more)"
+			if k > 0 {
+				tail = sep + tail
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.Grow(size + len(tail))
+	b.WriteString(head)
+	for i, s := range msgs[:k] {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(s)
+	}
+	b.WriteString(tail)
+	return b.String()
+}
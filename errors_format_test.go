@@ -0,0 +1,93 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestAggregateFormatting(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Error joins member messages",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("name", ""))
+				g.Add(sanity.NonZero("port", 0))
+				return g.Err().Error()
+			},
+			expected: "2 errors: name: must be non-empty; port: must be non-zero",
+		},
+		{
+			name: "Clamp sentinel is part of the message",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(1))
+				g.Add(sanity.NonEmpty("name", ""))
+				g.Add(sanity.NonZero("port", 0))
+				return g.Err().Error()
+			},
+			expected: "2 errors: name: must be non-empty; validation: 1 additional errors omitted (kept 1)",
+		},
+		{
+			name: "Message limit summarizes what does not fit",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithMessageLimit(50))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonEmpty("b", ""))
+				g.Add(sanity.NonEmpty("c", ""))
+				g.Add(sanity.NonEmpty("d", ""))
+				msg := g.Err().Error()
+				return []interface{}{msg, len(msg) <= 50}
+			},
+			expected: []interface{}{"4 errors: a: must be non-empty; (3 more)", true},
+		},
+		{
+			name: "Limit smaller than any member",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithMessageLimit(5))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonEmpty("b", ""))
+				return g.Err().Error()
+			},
+			expected: "2 errors: (2 more)",
+		},
+		{
+			name: "Limit is kept through prefixing",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithMessageLimit(45))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonEmpty("b", ""))
+				return sanity.WithPrefix("x", g.Err()).Error()
+			},
+			expected: "2 errors: x.a: must be non-empty; (1 more)",
+		},
+		{
+			name: "FormatList",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("name", ""))
+				g.Add(sanity.NonZero("port", 0))
+				return []string{
+					sanity.FormatList(g.Err(), "\n"),
+					sanity.FormatList(errors.New("x"), ", "),
+					sanity.FormatList(nil, ", "),
+				}
+			},
+			expected: []string{"name: must be non-empty\nport: must be non-zero", "x", ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
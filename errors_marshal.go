@@ -0,0 +1,46 @@
+package sanity
+
+import "encoding/json"
+
+// groupJSON is the JSON form of an aggregate.
+type groupJSON struct {
+	Count   int              `json:"count"`
+	Dropped int              `json:"dropped,omitempty"`
+	Errors  []FieldViolation `json:"errors"`
+}
+
+// MarshalJSON encodes the aggregate as
+//
+//	{"count":2,"dropped":1,"errors":[{"field":"port","code":"OUT_OF_RANGE","message":"..."},...]}
+//
+// with members in Iter order; count is the number of kept members and the
+// clamp sentinel is reported as "dropped" rather than as a member.
+func (m *multiError) MarshalJSON() ([]byte, error) {
+	out := groupJSON{Count: m.n, Dropped: m.dropped, Errors: make([]FieldViolation, 0, m.n)}
+	for i := 0; i < m.n; i++ {
+		out.Errors = append(out.Errors, violationOf(m.at(i)))
+	}
+	return json.Marshal(out)
+}
+
+// MarshalText renders one member per line, as FormatList(m, "\n").
+func (m *multiError) MarshalText() ([]byte, error) {
+	return []byte(FormatList(m, "\n")), nil
+}
+
+// ErrorsToMap returns the messages of err's members (see Errors) keyed by
+// field path, without the field prefix, in member order; members that are not
+// FieldErrors are listed under "". It returns nil for a nil err.
+//
+//	{{range index .Errors "email"}}<p class="error">{{.}}</p>{{end}}
+func ErrorsToMap(err error) map[string][]string {
+	if err == nil {
+		return nil
+	}
+	out := make(map[string][]string)
+	for e := range Errors(err) {
+		v := violationOf(e)
+		out[v.Field] = append(out[v.Field], v.Message)
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package sanity_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func marshalGroup(opts ...sanity.GuardOption) error {
+	g := sanity.NewGuard(append([]sanity.GuardOption{sanity.WithMaxErrors(0)}, opts...)...)
+	g.Add(sanity.OneOf("mode", "x", "auto", "manual"))
+	g.Add(sanity.NonBlank("email", ""))
+	g.Add(errors.New("disk full"))
+	g.Add(sanity.NonZero("email.retries", 0))
+	return g.Err()
+}
+
+func TestAggregateMarshaling(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "MarshalJSON: counts, codes and member order",
+			function: func() interface{} {
+				b, err := json.Marshal(marshalGroup())
+				return []interface{}{err == nil, string(b)}
+			},
+			expected: []interface{}{true, `{"count":4,"errors":[` +
+				`{"field":"mode","code":"NOT_IN_SET","message":"must be one of [auto, manual]","allowed":["auto","manual"]},` +
+				`{"field":"email","code":"NON_EMPTY","message":"must be non-empty"},` +
+				`{"message":"disk full"},` +
+				`{"field":"email.retries","code":"NON_ZERO","message":"must be non-zero"}]}`},
+		},
+		{
+			name: "MarshalJSON: dropped errors counted, sentinel not listed",
+			function: func() interface{} {
+				b, _ := json.Marshal(marshalGroup(sanity.WithMaxErrors(2)))
+				var out struct {
+					Count   int
+					Dropped int
+					Errors  []sanity.FieldViolation
+				}
+				_ = json.Unmarshal(b, &out)
+				return []int{out.Count, out.Dropped, len(out.Errors)}
+			},
+			expected: []int{2, 2, 2},
+		},
+		{
+			name: "MarshalText: one member per line",
+			function: func() interface{} {
+				tm, ok := marshalGroup(sanity.WithMaxErrors(2)).(encoding.TextMarshaler)
+				b, _ := tm.MarshalText()
+				return []interface{}{ok, string(b)}
+			},
+			expected: []interface{}{true, "mode: must be one of [auto, manual]\nemail: must be non-empty\n" +
+				"validation: 2 additional errors omitted (kept 2)"},
+		},
+		{
+			name: "ErrorsToMap groups messages by field",
+			function: func() interface{} {
+				m := sanity.ErrorsToMap(sanity.WithPrefix("user", marshalGroup()))
+				return []interface{}{len(m), m["user.mode"], m["user.email"][0], m["user"], sanity.ErrorsToMap(nil) == nil}
+			},
+			expected: []interface{}{4, []string{"must be one of [auto, manual]"}, "must be non-empty", []string{"disk full"}, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
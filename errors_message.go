@@ -0,0 +1,65 @@
+package sanity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// messager renders the message part of a typed error, without the field prefix.
+// redact omits offending values ("got ...").
+type messager interface {
+	message(redact bool) string
+}
+
+func (e NotNilError) Error() string     { return e.FieldName() + ": " + e.message(redacting()) }
+func (e NonZeroError) Error() string    { return e.FieldName() + ": " + e.message(redacting()) }
+func (e NonEmptyError) Error() string   { return e.FieldName() + ": " + e.message(redacting()) }
+func (e NotInSetError) Error() string   { return e.FieldName() + ": " + e.message(redacting()) }
+func (e LenAtLeastError) Error() string { return e.FieldName() + ": " + e.message(redacting()) }
+func (e LenAtMostError) Error() string  { return e.FieldName() + ": " + e.message(redacting()) }
+func (e LenBetweenError) Error() string { return e.FieldName() + ": " + e.message(redacting()) }
+func (e OutOfRangeError[T]) Error() string {
+	return e.FieldName() + ": " + e.message(redacting())
+}
+
+func (e NotNilError) message(bool) string   { return "must not be nil" }
+func (e NonZeroError) message(bool) string  { return "must be non-zero" }
+func (e NonEmptyError) message(bool) string { return "must be non-empty" }
+
+// The allowed values describe the schema, not the input, so they are kept
+// when redacting.
+func (e NotInSetError) message(bool) string {
+	allowed := e.AllowedValues()
+	if len(allowed) == 0 {
+		return "invalid value"
+	}
+	return "must be one of [" + strings.Join(allowed, ", ") + "]"
+}
+
+func (e LenAtLeastError) message(redact bool) string {
+	if redact {
+		return fmt.Sprintf("len must be >= %d", e.Want)
+	}
+	return fmt.Sprintf("len must be >= %d (got %d)", e.Want, e.Got)
+}
+
+func (e LenAtMostError) message(redact bool) string {
+	if redact {
+		return fmt.Sprintf("len must be <= %d", e.Want)
+	}
+	return fmt.Sprintf("len must be <= %d (got %d)", e.Want, e.Got)
+}
+
+func (e LenBetweenError) message(redact bool) string {
+	if redact {
+		return fmt.Sprintf("len must be in [%d,%d]", e.Min, e.Max)
+	}
+	return fmt.Sprintf("len must be in [%d,%d] (got %d)", e.Min, e.Max, e.Got)
+}
+
+func (e OutOfRangeError[T]) message(redact bool) string {
+	if redact {
+		return fmt.Sprintf("must be in [%v,%v]", e.Min, e.Max)
+	}
+	return fmt.Sprintf("must be in [%v,%v], got %v", e.Min, e.Max, e.Value())
+}
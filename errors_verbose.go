@@ -2,28 +2,5 @@
 
 package sanity
 
-import "fmt"
-
-func (e NotNilError) Error() string {
-	return e.FieldName() + ": must not be nil"
-}
-
-func (e NonZeroError) Error() string {
-	return e.FieldName() + ": must be non-zero"
-}
-
-func (e NonEmptyError) Error() string {
-	return e.FieldName() + ": must be non-empty"
-}
-
-func (e NotInSetError) Error() string {
-	return e.FieldName() + ": invalid value"
-}
-
-func (e LenAtLeastError) Error() string {
-	return fmt.Sprintf("%s: len must be >= %d (got %d)", e.FieldName(), e.Want, e.Got)
-}
-
-func (e OutOfRangeError[T]) Error() string {
-	return fmt.Sprintf("%s: must be in [%v,%v], got %v", e.FieldName(), e.Min, e.Max, e.Value())
-}
+// RedactBuild reports whether error strings omit offending values (-tags=redact).
+const RedactBuild = false
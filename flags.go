@@ -0,0 +1,54 @@
+package sanity
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrUnknownFlag is returned by CheckFlags for a rule naming an undefined flag.
+var ErrUnknownFlag = errors.New("sanity:unknown_flag")
+
+// CheckFlags runs rules, keyed by flag name, after fs.Parse and returns the
+// aggregate of their failures in flag-name order. Errors that are not
+// FieldErrors are attached to the flag name with a FieldPathError; nil rules
+// are skipped. A rule for a flag fs does not define is a programming error,
+// returned immediately as ErrUnknownFlag.
+//
+//	port := fs.Int("port", 8080, "listen port")
+//	fs.Parse(os.Args[1:])
+//	err := sanity.CheckFlags(fs, map[string]sanity.Check{
+//		"port": func() error { return sanity.InRangeNum("port", *port, 1, 65535) },
+//	})
+func CheckFlags(fs *flag.FlagSet, rules map[string]Check) error {
+	return CheckFlagSet[flag.Flag](fs, rules)
+}
+
+// CheckFlagSet is CheckFlags for any flag set with a Lookup method, such as
+// *pflag.FlagSet. The flag type cannot be inferred and must be given:
+//
+//	sanity.CheckFlagSet[pflag.Flag](cmd.Flags(), rules)
+func CheckFlagSet[F any, S interface{ Lookup(name string) *F }](fs S, rules map[string]Check) error {
+	g := NewGuard(WithMaxErrors(0))
+	for _, name := range slices.Sorted(maps.Keys(rules)) {
+		if fs.Lookup(name) == nil {
+			return fmt.Errorf("sanity: CheckFlags: flag %q: %w", name, ErrUnknownFlag)
+		}
+		check := rules[name]
+		if check == nil {
+			continue
+		}
+		err := check()
+		if err == nil {
+			continue
+		}
+		var fe FieldError
+		if !errors.As(err, &fe) {
+			err = FieldPathError{Path: name, Err: err}
+		}
+		g.Merge(err)
+	}
+	return g.Err()
+}
@@ -0,0 +1,99 @@
+package sanity_test
+
+import (
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+// pflagSet mimics *pflag.FlagSet's Lookup.
+type pflag struct{ Name string }
+type pflagSet map[string]*pflag
+
+func (s pflagSet) Lookup(name string) *pflag { return s[name] }
+
+func TestCheckFlags(t *testing.T) {
+	newFlags := func(args ...string) (*flag.FlagSet, *int, *string) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		port := fs.Int("port", 8080, "")
+		mode := fs.String("mode", "auto", "")
+		_ = fs.Parse(args)
+		return fs, port, mode
+	}
+
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Passing rules -> nil",
+			function: func() interface{} {
+				fs, port, mode := newFlags("-port", "80")
+				return sanity.CheckFlags(fs, map[string]sanity.Check{
+					"port": func() error { return sanity.InRangeNum("port", *port, 1, 65535) },
+					"mode": func() error { return sanity.OneOf("mode", *mode, "auto", "manual") },
+				}) == nil
+			},
+			expected: true,
+		},
+		{
+			name: "Failures aggregated in flag-name order",
+			function: func() interface{} {
+				fs, port, mode := newFlags("-port", "0", "-mode", "x")
+				err := sanity.CheckFlags(fs, map[string]sanity.Check{
+					"port": func() error { return sanity.InRangeNum("port", *port, 1, 65535) },
+					"mode": func() error { return sanity.OneOf("mode", *mode, "auto", "manual") },
+				})
+				return fieldsOf(err)
+			},
+			expected: []string{"mode", "port"},
+		},
+		{
+			name: "Plain errors attached to the flag name, nil rules skipped",
+			function: func() interface{} {
+				fs, _, _ := newFlags()
+				boom := errors.New("unreachable")
+				err := sanity.CheckFlags(fs, map[string]sanity.Check{
+					"port": func() error { return boom },
+					"mode": nil,
+				})
+				return []interface{}{fieldsOf(err), errors.Is(err, boom), err.Error()}
+			},
+			expected: []interface{}{[]string{"port"}, true, "port: unreachable"},
+		},
+		{
+			name: "Undefined flag -> ErrUnknownFlag",
+			function: func() interface{} {
+				fs, _, _ := newFlags()
+				err := sanity.CheckFlags(fs, map[string]sanity.Check{"prot": nil})
+				return errors.Is(err, sanity.ErrUnknownFlag)
+			},
+			expected: true,
+		},
+		{
+			name: "CheckFlagSet works with pflag-style sets",
+			function: func() interface{} {
+				fs := pflagSet{"replicas": {Name: "replicas"}}
+				err := sanity.CheckFlagSet[pflag](fs, map[string]sanity.Check{
+					"replicas": func() error { return sanity.Positive("replicas", -1) },
+				})
+				return []bool{
+					errors.Is(err, sanity.ErrSign),
+					errors.Is(sanity.CheckFlagSet[pflag](fs, map[string]sanity.Check{"x": nil}), sanity.ErrUnknownFlag),
+				}
+			},
+			expected: []bool{true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
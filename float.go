@@ -27,3 +27,44 @@ func ClampFinite[T Float](p *T, def T) {
 		*p = def
 	}
 }
+
+// NormalizeAngleDeg wraps an angle in degrees into [0,360).
+func NormalizeAngleDeg[T Float](a T) T {
+	return Wrap(a, 0, 360)
+}
+
+// NormalizeAngleRad wraps an angle in radians into [0,2π).
+func NormalizeAngleRad[T Float](a T) T {
+	return Wrap(a, 0, T(2*math.Pi))
+}
+
+// ClampPercent clamps *p into [0,100]; NaN becomes 0.
+func ClampPercent(p *float64) {
+	clampUnitRange(p, 100)
+}
+
+// ClampUnit clamps *p into [0,1]; NaN becomes 0.
+func ClampUnit(p *float64) {
+	clampUnitRange(p, 1)
+}
+
+func clampUnitRange(p *float64, max float64) {
+	if math.IsNaN(*p) {
+		*p = 0
+		return
+	}
+	Clamp(p, 0, max)
+}
+
+// RatioOrDefault returns num/den, or def if den is zero or the result is not
+// finite (NaN or ±Inf inputs).
+func RatioOrDefault(num, den, def float64) float64 {
+	if den == 0 {
+		return def
+	}
+	r := num / den
+	if math.IsNaN(r) || math.IsInf(r, 0) {
+		return def
+	}
+	return r
+}
@@ -85,6 +85,84 @@ func TestFloatFunctions(t *testing.T) {
 			},
 			expected: 1.25,
 		},
+		{
+			name: "NormalizeAngleDeg negative",
+			function: func() interface{} {
+				return sanity.NormalizeAngleDeg(-90.0)
+			},
+			expected: 270.0,
+		},
+		{
+			name: "NormalizeAngleDeg full turn",
+			function: func() interface{} {
+				return sanity.NormalizeAngleDeg(float32(720))
+			},
+			expected: float32(0),
+		},
+		{
+			name: "NormalizeAngleRad",
+			function: func() interface{} {
+				return sanity.NormalizeAngleRad(-math.Pi / 2)
+			},
+			expected: 3 * math.Pi / 2,
+		},
+		{
+			name: "ClampPercent above",
+			function: func() interface{} {
+				v := 120.0
+				sanity.ClampPercent(&v)
+				return v
+			},
+			expected: 100.0,
+		},
+		{
+			name: "ClampPercent NaN -> 0",
+			function: func() interface{} {
+				v := math.NaN()
+				sanity.ClampPercent(&v)
+				return v
+			},
+			expected: 0.0,
+		},
+		{
+			name: "ClampUnit below",
+			function: func() interface{} {
+				v := -0.2
+				sanity.ClampUnit(&v)
+				return v
+			},
+			expected: 0.0,
+		},
+		{
+			name: "ClampUnit in range",
+			function: func() interface{} {
+				v := 0.25
+				sanity.ClampUnit(&v)
+				return v
+			},
+			expected: 0.25,
+		},
+		{
+			name: "RatioOrDefault",
+			function: func() interface{} {
+				return sanity.RatioOrDefault(1, 4, -1)
+			},
+			expected: 0.25,
+		},
+		{
+			name: "RatioOrDefault zero denominator",
+			function: func() interface{} {
+				return sanity.RatioOrDefault(1, 0, -1)
+			},
+			expected: -1.0,
+		},
+		{
+			name: "RatioOrDefault non-finite",
+			function: func() interface{} {
+				return sanity.RatioOrDefault(math.Inf(1), 2, -1)
+			},
+			expected: -1.0,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,97 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+)
+
+// ErrInvalidFormat is the category sentinel of FormatError.
+var ErrInvalidFormat = errors.New("sanity:invalid_format")
+
+// Format names reported in FormatError.Format.
+const (
+	FormatEmail    = "email"
+	FormatUUID     = "uuid"
+	FormatSemver   = "semver"
+	FormatUTF8     = "utf-8"
+	FormatInteger  = "integer"
+	FormatDuration = "duration"
+)
+
+// FormatError indicates a string that does not match a well-known format.
+type FormatError struct {
+	Field  string
+	Format string // FormatEmail, FormatUUID, FormatSemver, FormatUTF8, ...
+	Got    string
+}
+
+func (e FormatError) Unwrap() error     { return ErrInvalidFormat }
+func (e FormatError) FieldName() string { return e.Field }
+func (e FormatError) Code() string      { return CodeInvalidFormat }
+func (e FormatError) Error() string     { return e.FieldName() + ": " + e.message(redacting()) }
+func (e FormatError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e FormatError) message(redact bool) string {
+	if redact {
+		return "must be a valid " + e.Format
+	}
+	return fmt.Sprintf("must be a valid %s, got %q", e.Format, e.Got)
+}
+
+// ValidEmail checks that s is a bare RFC 5322 address ("a@example.com"),
+// without a display name or angle brackets.
+func ValidEmail(name, s string) error {
+	a, err := mail.ParseAddress(s)
+	if err != nil || a.Name != "" || a.Address != s {
+		return FormatError{Field: name, Format: FormatEmail, Got: s}
+	}
+	return nil
+}
+
+// ValidUUID checks that s is a UUID in canonical 8-4-4-4-12 hex form
+// (either case); version and variant bits are not checked.
+func ValidUUID(name, s string) error {
+	if !isUUID(s) {
+		return FormatError{Field: name, Format: FormatUUID, Got: s}
+	}
+	return nil
+}
+
+// semverRe is the regular expression recommended by semver.org.
+var semverRe = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// ValidSemver checks that s is a Semantic Versioning 2.0.0 version such as
+// "1.2.3-rc.1+build.5". A leading "v" is not part of the spec and is rejected.
+func ValidSemver(name, s string) error {
+	if !semverRe.MatchString(s) {
+		return FormatError{Field: name, Format: FormatSemver, Got: s}
+	}
+	return nil
+}
+
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestFormats(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "ValidEmail",
+			function: func() interface{} {
+				return []bool{
+					sanity.ValidEmail("email", "ops@example.com") == nil,
+					sanity.ValidEmail("email", "first.last+tag@sub.example.org") == nil,
+					errors.Is(sanity.ValidEmail("email", "Ops <ops@example.com>"), sanity.ErrInvalidFormat),
+					errors.Is(sanity.ValidEmail("email", "ops@"), sanity.ErrInvalidFormat),
+					errors.Is(sanity.ValidEmail("email", "example.com"), sanity.ErrInvalidFormat),
+					errors.Is(sanity.ValidEmail("email", " ops@example.com"), sanity.ErrInvalidFormat),
+				}
+			},
+			expected: []bool{true, true, true, true, true, true},
+		},
+		{
+			name: "ValidUUID",
+			function: func() interface{} {
+				return []bool{
+					sanity.ValidUUID("id", "123e4567-e89b-12d3-a456-426614174000") == nil,
+					sanity.ValidUUID("id", "123E4567-E89B-12D3-A456-426614174000") == nil,
+					errors.Is(sanity.ValidUUID("id", "123e4567e89b12d3a456426614174000"), sanity.ErrInvalidFormat),
+					errors.Is(sanity.ValidUUID("id", "123e4567-e89b-12d3-a456-42661417400g"), sanity.ErrInvalidFormat),
+					errors.Is(sanity.ValidUUID("id", "{123e4567-e89b-12d3-a456-426614174000}"), sanity.ErrInvalidFormat),
+				}
+			},
+			expected: []bool{true, true, true, true, true},
+		},
+		{
+			name: "ValidSemver",
+			function: func() interface{} {
+				return []bool{
+					sanity.ValidSemver("v", "0.4.0") == nil,
+					sanity.ValidSemver("v", "1.2.3-rc.1+build.5") == nil,
+					errors.Is(sanity.ValidSemver("v", "v1.2.3"), sanity.ErrInvalidFormat),
+					errors.Is(sanity.ValidSemver("v", "1.2"), sanity.ErrInvalidFormat),
+					errors.Is(sanity.ValidSemver("v", "01.2.3"), sanity.ErrInvalidFormat),
+					errors.Is(sanity.ValidSemver("v", "1.2.3-"), sanity.ErrInvalidFormat),
+				}
+			},
+			expected: []bool{true, true, true, true, true, true},
+		},
+		{
+			name: "FormatError fields, code and redaction",
+			function: func() interface{} {
+				err := sanity.ValidUUID("id", "nope")
+				var fe sanity.FormatError
+				return []interface{}{
+					errors.As(err, &fe), fe.Format, fe.Got, sanity.CodeOf(err),
+					sanity.Redacted(sanity.WithPrefix("user", err)).Error(),
+				}
+			},
+			expected: []interface{}{true, "uuid", "nope", "INVALID_FORMAT", "user.id: must be a valid uuid"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
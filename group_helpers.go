@@ -1,21 +1,18 @@
 package sanity
 
-import "errors"
+import (
+	"errors"
+	"iter"
+)
 
-// GroupAsSlice appends underlying errors into dst and returns the result.
+// GroupAsSlice appends the members of err (see Errors) into dst and returns
+// the result.
 func GroupAsSlice(err error, dst []error) []error {
-	if err == nil {
-		return dst
-	}
-	var eg ErrorGroup
-	if errors.As(err, &eg) {
-		eg.Iter(func(e error) bool {
-			dst = append(dst, e)
-			return true
-		})
-		return dst
-	}
-	return append(dst, err)
+	eachMember(err, func(e error) bool {
+		dst = append(dst, e)
+		return true
+	})
+	return dst
 }
 
 // GroupLen reports the number of underlying errors if err is this package's group.
@@ -26,3 +23,153 @@ func GroupLen(err error) (int, bool) {
 	}
 	return 0, false
 }
+
+// GroupAt returns the i-th member of err if it is this package's group, in Iter
+// order (the clamp sentinel, if any, is last). It is O(1), for paging through
+// or sampling large groups alongside GroupLen.
+func GroupAt(err error, i int) (error, bool) {
+	m, ok := err.(*multiError)
+	if !ok || i < 0 || i >= m.Len() {
+		return nil, false
+	}
+	if i < m.n {
+		return m.at(i), true
+	}
+	return m.clamped()
+}
+
+// Errors iterates over err's members if it is (or wraps) an ErrorGroup,
+// otherwise over err itself; nil yields nothing. An errors.Join result (or any
+// other error with Unwrap() []error) yields the members of each joined error
+// in order, so groups joined with other errors are not cut short.
+//
+//	for e := range sanity.Errors(err) { ... }
+func Errors(err error) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		eachMember(err, yield)
+	}
+}
+
+// eachMember implements Errors; it returns false once yield has. The wrap
+// chain of err is followed to the first group, so an ErrorGroup or joined
+// errors under fmt.Errorf("...: %w") are expanded too.
+func eachMember(err error, yield func(error) bool) bool {
+	if err == nil {
+		return true
+	}
+	for e := err; e != nil; {
+		if eg, ok := e.(ErrorGroup); ok {
+			return iterGroup(eg, yield)
+		}
+		if j, ok := e.(interface{ Unwrap() []error }); ok {
+			for _, m := range j.Unwrap() {
+				if !eachMember(m, yield) {
+					return false
+				}
+			}
+			return true
+		}
+		u, ok := e.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		e = u.Unwrap()
+	}
+	var eg ErrorGroup
+	if errors.As(err, &eg) { // groups exposed through an As method
+		return iterGroup(eg, yield)
+	}
+	return yield(err)
+}
+
+func iterGroup(eg ErrorGroup, yield func(error) bool) bool {
+	more := true
+	eg.Iter(func(e error) bool {
+		more = yield(e)
+		return more
+	})
+	return more
+}
+
+// GroupFilter returns the members of err (see Errors) for which pred is true.
+func GroupFilter(err error, pred func(error) bool) []error {
+	var out []error
+	for e := range Errors(err) {
+		if pred(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// GroupByField partitions the members of err by field path; members that are
+// not FieldErrors (including the clamp sentinel) are listed under "".
+func GroupByField(err error) map[string][]error {
+	out := make(map[string][]error)
+	for e := range Errors(err) {
+		var field string
+		var fe FieldError
+		if errors.As(e, &fe) {
+			field = fe.FieldName()
+		}
+		out[field] = append(out[field], e)
+	}
+	return out
+}
+
+// FirstError returns the first member of err (see Errors): the first kept
+// failure of an aggregate, err itself otherwise, nil for nil.
+func FirstError(err error) error {
+	var first error
+	eachMember(err, func(e error) bool {
+		first = e
+		return false
+	})
+	return first
+}
+
+// CategoryCount reports how many members of err (see Errors) match sentinel
+// under errors.Is, for summaries such as "7 range errors, 2 missing fields":
+//
+//	n := sanity.CategoryCount(err, sanity.ErrOutOfRange)
+//
+// Members dropped by a cap are not counted; CategoryCount(err, ErrClamped) is
+// 1 if any were.
+func CategoryCount(err error, sentinel error) int {
+	n := 0
+	eachMember(err, func(e error) bool {
+		if errors.Is(e, sentinel) {
+			n++
+		}
+		return true
+	})
+	return n
+}
+
+// Collect returns the aggregate of the non-nil errs, one member each, as a
+// Guard with WithMaxErrors(0) builds it with Add; nil if there are none.
+func Collect(errs ...error) error {
+	return collect(errs, (*Guard).Add)
+}
+
+// Join is like Collect, but folds aggregates and errors.Join results in
+// member by member (see Guard.Merge). The all-nil case does not allocate,
+// which is why code generated by cmd/sanitygen ends with it.
+func Join(errs ...error) error {
+	return collect(errs, (*Guard).Merge)
+}
+
+func collect(errs []error, add func(*Guard, error)) error {
+	i := 0
+	for i < len(errs) && errs[i] == nil {
+		i++
+	}
+	if i == len(errs) {
+		return nil
+	}
+	g := NewGuard(WithMaxErrors(0))
+	for _, err := range errs[i:] {
+		add(&g, err)
+	}
+	return g.Err()
+}
@@ -0,0 +1,221 @@
+package sanity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestGroupHelpers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Errors ranges over aggregate members",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonZero("b", 0))
+				g.Add(sanity.NonZero("c", 0))
+				var fields []string
+				for e := range sanity.Errors(g.Err()) {
+					fields = append(fields, fieldOf(e))
+				}
+				return fields
+			},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name: "Errors supports break and wrapped groups",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonZero("b", 0))
+				n := 0
+				for range sanity.Errors(fmt.Errorf("load: %w", g.Err())) {
+					n++
+					break
+				}
+				return n
+			},
+			expected: 1,
+		},
+		{
+			name: "Errors yields a single error or nothing",
+			function: func() interface{} {
+				var got []error
+				for e := range sanity.Errors(nil) {
+					got = append(got, e)
+				}
+				x := errors.New("x")
+				for e := range sanity.Errors(x) {
+					got = append(got, e)
+				}
+				return got
+			},
+			expected: []error{errors.New("x")},
+		},
+		{
+			name: "ErrorGroup.All",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(1))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonEmpty("b", ""))
+				var n int
+				for e := range g.Err().(sanity.ErrorGroup).All() {
+					if errors.Is(e, sanity.ErrClamped) || errors.Is(e, sanity.ErrNonEmpty) {
+						n++
+					}
+				}
+				return n
+			},
+			expected: 2,
+		},
+		{
+			name: "GroupLen and GroupAt page through members",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(6))
+				for _, f := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+					g.Add(sanity.NonEmpty(f, ""))
+				}
+				err := g.Err()
+				n, ok := sanity.GroupLen(err)
+				var fields []string
+				for i := 0; i < n; i++ {
+					e, _ := sanity.GroupAt(err, i)
+					fields = append(fields, fieldOf(e))
+				}
+				_, outOfRange := sanity.GroupAt(err, n)
+				last, _ := sanity.GroupAt(err, n-1)
+				return []interface{}{n, ok, fields, outOfRange, errors.Is(last, sanity.ErrClamped)}
+			},
+			expected: []interface{}{7, true, []string{"a", "b", "c", "d", "e", "f", ""}, false, true},
+		},
+		{
+			name: "GroupAt on non-groups",
+			function: func() interface{} {
+				_, ok1 := sanity.GroupAt(sanity.NonEmpty("a", ""), 0)
+				_, ok2 := sanity.GroupAt(nil, 0)
+				return []bool{ok1, ok2}
+			},
+			expected: []bool{false, false},
+		},
+		{
+			name: "GroupFilter keeps matching members",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonZero("b", 0))
+				g.Add(sanity.NonEmpty("c", ""))
+				var fields []string
+				for _, e := range sanity.GroupFilter(g.Err(), func(e error) bool { return errors.Is(e, sanity.ErrNonEmpty) }) {
+					fields = append(fields, fieldOf(e))
+				}
+				return fields
+			},
+			expected: []string{"a", "c"},
+		},
+		{
+			name: "GroupByField maps failures back to fields",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(3))
+				g.Add(sanity.NonEmpty("name", ""))
+				g.Add(sanity.StrLenAtLeast("name", "", 3))
+				g.Add(errors.New("backend unavailable"))
+				g.Add(sanity.NonZero("port", 0)) // dropped
+				counts := map[string]int{}
+				for f, errs := range sanity.GroupByField(g.Err()) {
+					counts[f] = len(errs)
+				}
+				return counts
+			},
+			expected: map[string]int{"name": 2, "": 2},
+		},
+		{
+			name: "Errors expands errors.Join results",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonZero("b", 0))
+				joined := errors.Join(g.Err(), sanity.NonZero("c", 0))
+				n := 0
+				for range sanity.Errors(joined) {
+					n++
+					break
+				}
+				return []interface{}{fieldsOf(fmt.Errorf("load: %w", joined)), n}
+			},
+			expected: []interface{}{[]string{"a", "b", "c"}, 1},
+		},
+		{
+			name: "Collect keeps each non-nil error as one member",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonZero("b", 0))
+				err := sanity.Collect(nil, sanity.NonZero("port", 0), nil, g.Err(), errors.New("x"))
+				n, _ := sanity.GroupLen(err)
+				return []interface{}{sanity.Collect(nil, nil) == nil, n, errors.Is(err, sanity.ErrNonEmpty)}
+			},
+			expected: []interface{}{true, 3, true},
+		},
+		{
+			name: "Join skips nils and flattens aggregates",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonZero("b", 0))
+				err := sanity.Join(nil, sanity.WithPrefix("tls", g.Err()), nil, sanity.NonZero("port", 0))
+				return []interface{}{sanity.Join(nil, nil) == nil, sanity.Join() == nil, fieldsOf(err)}
+			},
+			expected: []interface{}{true, true, []string{"tls.a", "tls.b", "port"}},
+		},
+		{
+			name: "FirstError returns the first member",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonZero("b", 0))
+				x := errors.New("x")
+				return []interface{}{
+					fieldOf(sanity.FirstError(fmt.Errorf("load: %w", g.Err()))),
+					sanity.FirstError(x) == x,
+					sanity.FirstError(nil) == nil,
+				}
+			},
+			expected: []interface{}{"a", true, true},
+		},
+		{
+			name: "CategoryCount counts members by sentinel",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(3))
+				g.Add(sanity.InRangeNum("a", 0, 1, 10))
+				g.Add(sanity.NonZero("b", 0))
+				g.Add(sanity.InRangeNum("c", 0, 1, 10))
+				g.Add(sanity.InRangeNum("d", 0, 1, 10))
+				err := errors.Join(g.Err(), sanity.InRangeNum("e", 0, 1, 10))
+				return []int{
+					sanity.CategoryCount(err, sanity.ErrOutOfRange),
+					sanity.CategoryCount(err, sanity.ErrNonZero),
+					sanity.CategoryCount(err, sanity.ErrNotNil),
+					sanity.CategoryCount(err, sanity.ErrClamped),
+					sanity.CategoryCount(nil, sanity.ErrOutOfRange),
+				}
+			},
+			expected: []int{3, 1, 0, 1, 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
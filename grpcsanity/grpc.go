@@ -0,0 +1,77 @@
+// Package grpcsanity maps sanity validation errors to gRPC InvalidArgument
+// statuses with BadRequest field violations.
+//
+// Like the other adapters it does not import grpc: the interceptor is generic
+// over grpc's info and handler types, and the status itself is built by a
+// StatusFunc the service supplies once, e.g.
+//
+//	func newStatus(code uint32, msg string, vs []grpcsanity.FieldViolation) error {
+//		br := &errdetails.BadRequest{}
+//		for _, v := range vs {
+//			br.FieldViolations = append(br.FieldViolations,
+//				&errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Description})
+//		}
+//		st, err := status.New(codes.Code(code), msg).WithDetails(br)
+//		if err != nil {
+//			return status.Error(codes.Code(code), msg)
+//		}
+//		return st.Err()
+//	}
+//
+//	grpc.NewServer(grpc.UnaryInterceptor(
+//		grpcsanity.UnaryServerInterceptor[*grpc.UnaryServerInfo, grpc.UnaryHandler](newStatus)))
+package grpcsanity
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sessaidi/sanity"
+)
+
+// CodeInvalidArgument is the value of grpc's codes.InvalidArgument.
+const CodeInvalidArgument uint32 = 3
+
+// FieldViolation mirrors errdetails.BadRequest_FieldViolation.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// StatusFunc builds the error returned to the client from a gRPC code, a
+// summary message and the field violations.
+type StatusFunc func(code uint32, msg string, violations []FieldViolation) error
+
+// Violations returns one FieldViolation per member of err (see sanity.Errors),
+// with the field path and its message without the field prefix, as in
+// sanity.ErrorPayload.
+func Violations(err error) []FieldViolation {
+	var out []FieldViolation
+	for _, v := range sanity.NewErrorPayload(err).Errors {
+		out = append(out, FieldViolation{Field: v.Field, Description: v.Message})
+	}
+	return out
+}
+
+// Convert returns newStatus(CodeInvalidArgument, ...) if err is or contains a
+// sanity.FieldError, and err unchanged otherwise (including nil).
+func Convert(err error, newStatus StatusFunc) error {
+	var fe sanity.FieldError
+	if !errors.As(err, &fe) {
+		return err
+	}
+	return newStatus(CodeInvalidArgument, "invalid argument", Violations(err))
+}
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that passes
+// handler errors through Convert. Instantiate it with grpc's types:
+//
+//	grpcsanity.UnaryServerInterceptor[*grpc.UnaryServerInfo, grpc.UnaryHandler](newStatus)
+func UnaryServerInterceptor[Info any, Handler ~func(ctx context.Context, req any) (any, error)](
+	newStatus StatusFunc,
+) func(ctx context.Context, req any, info Info, handler Handler) (any, error) {
+	return func(ctx context.Context, req any, _ Info, handler Handler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, Convert(err, newStatus)
+	}
+}
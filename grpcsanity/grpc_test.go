@@ -0,0 +1,94 @@
+package grpcsanity_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+	"github.com/sessaidi/sanity/grpcsanity"
+)
+
+// Stand-ins for *grpc.UnaryServerInfo and grpc.UnaryHandler.
+type unaryServerInfo struct{ FullMethod string }
+type unaryHandler func(ctx context.Context, req any) (any, error)
+
+type statusError struct {
+	code       uint32
+	msg        string
+	violations []grpcsanity.FieldViolation
+}
+
+func (e *statusError) Error() string { return fmt.Sprintf("code %d: %s", e.code, e.msg) }
+
+func newStatus(code uint32, msg string, vs []grpcsanity.FieldViolation) error {
+	return &statusError{code: code, msg: msg, violations: vs}
+}
+
+var intercept = grpcsanity.UnaryServerInterceptor[*unaryServerInfo, unaryHandler](newStatus)
+
+func call(err error) (any, error) {
+	return intercept(context.Background(), "req", &unaryServerInfo{FullMethod: "/svc/Get"},
+		func(ctx context.Context, req any) (any, error) { return req, err })
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Success passes through",
+			function: func() interface{} {
+				resp, err := call(nil)
+				return []interface{}{resp, err == nil}
+			},
+			expected: []interface{}{"req", true},
+		},
+		{
+			name: "Non-validation errors pass through",
+			function: func() interface{} {
+				boom := errors.New("boom")
+				_, err := call(boom)
+				return err == boom
+			},
+			expected: true,
+		},
+		{
+			name: "Aggregate becomes InvalidArgument with violations",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.InRangeNum("page_size", 500, 1, 100))
+				g.Add(sanity.OneOf("order", "up", "asc", "desc"))
+				_, err := call(g.Err())
+				var se *statusError
+				ok := errors.As(err, &se)
+				return []interface{}{ok, se.code, se.msg, se.violations[1], se.violations[0].Field}
+			},
+			expected: []interface{}{
+				true, grpcsanity.CodeInvalidArgument, "invalid argument",
+				grpcsanity.FieldViolation{Field: "order", Description: "must be one of [asc, desc]"},
+				"page_size",
+			},
+		},
+		{
+			name: "Violations keep prefixed field paths",
+			function: func() interface{} {
+				err := sanity.WithPrefix("req", sanity.NonBlank("name", " "))
+				return grpcsanity.Violations(err)
+			},
+			expected: []grpcsanity.FieldViolation{{Field: "req.name", Description: "must be non-empty"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -3,24 +3,57 @@ package sanity
 import (
 	"errors"
 	"fmt"
+	"iter"
 	"sync"
 )
 
 type Guard struct {
-	// SSO (small-struct optimization) for first 4 errors
-	e0, e1, e2, e3 error
-	more           []error
-	n              int // kept errors
+	// The first kept error lives inline; a second kept (or any dropped) error
+	// moves storage into agg, whose own SSO holds the first 4 errors.
+	e0     error
+	agg    *multiError
+	sealed bool // agg was handed out by Err(); copy before mutating
+	n      int  // kept errors
+
+	// Scoping: a scope prefixes errors and forwards them to parent; all
+	// state lives in the root Guard.
+	parent *Guard
+	prefix string
+	label  string // check name for errors without a field (Named)
+	redact bool   // record errors rendered without offending values
+
+	flatten bool // record group members individually (WithFlatten)
+
+	dedup bool             // collapse repeats (WithDedup)
+	seen  map[dedupKey]int // dedup key -> index of the kept error
+
+	sorted    bool        // Err() orders members by field (WithSortedErrors)
+	sortedAgg *multiError // sorted copy of agg, built when agg is sealed
+
+	base forkBase // state inherited from the parent, for Fork/Adopt
 
 	// Controls
 	max          int         // 0 -> unlimited; 1 -> first-error (default)
-	compactRatio int         // 0 -> default(2); used only when not thread-safe
+	maxPerCat    int         // 0 -> unlimited; kept errors per category (WithMaxPerCategory)
+	compactRatio int         // 0 -> default(2); spare 'more' capacity tolerated before Err() compacts
+	parallelism  int         // 0 -> GOMAXPROCS; RunParallel worker bound
+	msgLimit     int         // 0 -> unlimited; cap on the aggregate's Error() length
+	onError      func(error) // called for every failure, kept or not (WithOnError)
 	mu           sync.Locker // nil => no locking; else a real mutex
 
 	// Stats
-	checks   int // closures evaluated via AddCheck/Run/CheckLazy
-	failures int // non-nil errors seen (kept + dropped)
-	dropped  int // errors dropped due to cap
+	checks   int            // closures evaluated via AddCheck/Run/CheckLazy
+	failures int            // non-nil errors seen (kept + dropped)
+	dropped  int            // errors dropped due to cap
+	byCode   categoryCounts // failures per category (StatsByCategory)
+	keptCode categoryCounts // kept errors per category, tracked when maxPerCat > 0
+
+	warnings []error // soft findings; never capped, never part of Err()
+
+	timing  bool          // record per-check durations (WithTiming)
+	timings []CheckTiming // in completion order
+
+	callers bool // record call sites of kept errors (WithCallerInfo)
 }
 
 // GuardOption configures Guard behavior.
@@ -35,8 +68,30 @@ func WithMaxErrors(n int) GuardOption {
 	return func(g *Guard) { g.max = n }
 }
 
-// WithCompactRatio sets the compaction ratio for the 'more' slice
-// when not thread-safe. r <= 0 defaults to 2. Ignored if thread-safe.
+// WithMaxPerCategory keeps at most n errors per category (error code, see
+// StatsByCategory), dropping further ones like the global cap does, so one
+// noisy rule cannot crowd out the others. It applies in addition to
+// WithMaxErrors, so it is usually combined with WithMaxErrors(0). n <= 0
+// means no per-category cap.
+func WithMaxPerCategory(n int) GuardOption {
+	if n < 0 {
+		n = 0
+	}
+	return func(g *Guard) { g.maxPerCat = n }
+}
+
+// WithFlatten makes Add (and everything built on it: Check, AddCheck, Run,
+// ...) record the members of an added ErrorGroup or errors.Join result
+// individually, as Merge does, instead of as one nested member. Counts, caps,
+// dedup and Iter then see every failure of composed validations. AddKeep
+// reports true only if every member was kept. Groups wrapped by another error
+// (fmt.Errorf with %w) are recorded as is, keeping their context.
+func WithFlatten() GuardOption {
+	return func(g *Guard) { g.flatten = true }
+}
+
+// WithCompactRatio sets how much spare capacity the 'more' slice may carry
+// before Err() compacts it: cap > r*len triggers a copy. r <= 0 defaults to 2.
 func WithCompactRatio(r int) GuardOption {
 	return func(g *Guard) { g.compactRatio = r }
 }
@@ -46,6 +101,18 @@ func WithThreadSafe() GuardOption {
 	return func(g *Guard) { g.mu = &sync.Mutex{} }
 }
 
+// WithOnError calls fn with every recorded failure as it is added (kept or
+// dropped), e.g. to count failures in metrics. fn runs outside the Guard's
+// lock, on the goroutine that added the error.
+func WithOnError(fn func(err error)) GuardOption {
+	return func(g *Guard) { g.onError = fn }
+}
+
+// WithFieldPrefix prefixes the field path of every error recorded by the Guard.
+func WithFieldPrefix(prefix string) GuardOption {
+	return func(g *Guard) { g.prefix = prefix }
+}
+
 // NewGuard constructs a Guard. Default is first-error (max=1).
 func NewGuard(opts ...GuardOption) Guard {
 	g := Guard{max: 1}
@@ -55,6 +122,21 @@ func NewGuard(opts ...GuardOption) Guard {
 	return g
 }
 
+// Scope returns a view of gd whose recorded errors get their field path
+// prefixed ("server" + "tls" => "server.tls.cert"). The scope shares storage,
+// cap and stats with gd; its Err() returns the whole aggregate.
+func (gd *Guard) Scope(prefix string) *Guard {
+	return &Guard{parent: gd, prefix: prefix}
+}
+
+// root returns the Guard that owns the state.
+func (gd *Guard) root() *Guard {
+	for gd.parent != nil {
+		gd = gd.parent
+	}
+	return gd
+}
+
 func (gd *Guard) lock() {
 	if gd.mu != nil {
 		gd.mu.Lock()
@@ -72,26 +154,123 @@ type MGStats struct {
 	Failures int
 	Kept     int
 	Dropped  int
+	Warnings int
 }
 
 func (gd *Guard) Stats() MGStats {
+	gd = gd.root()
 	gd.lock()
 	defer gd.unlock()
-	return MGStats{Checks: gd.checks, Failures: gd.failures, Kept: gd.n, Dropped: gd.dropped}
+	return MGStats{Checks: gd.checks, Failures: gd.failures, Kept: gd.n, Dropped: gd.dropped, Warnings: len(gd.warnings)}
 }
 
-// Reset clears all state for reuse.
+// CategoryOther is the StatsByCategory key for failures without an error code.
+const CategoryOther = "OTHER"
+
+// StatsByCategory returns the number of failures (kept or dropped) per error
+// code, e.g. {"NON_EMPTY": 3, "OUT_OF_RANGE": 1}, for labeled metrics.
+// Failures without a code are counted under CategoryOther.
+func (gd *Guard) StatsByCategory() map[string]int {
+	gd = gd.root()
+	gd.lock()
+	defer gd.unlock()
+	return gd.byCode.toMap()
+}
+
+func (gd *Guard) countCategoryLocked(code string, n int) {
+	gd.byCode.add(code, n)
+}
+
+// categoryCounts keeps the first few categories inline, so counting does not
+// allocate in the common case of a handful of distinct failure kinds.
+type categoryCounts struct {
+	keys [4]string
+	vals [4]int
+	more map[string]int
+}
+
+func (c *categoryCounts) add(code string, n int) {
+	for i := range c.keys {
+		switch c.keys[i] {
+		case code:
+			c.vals[i] += n
+			return
+		case "":
+			c.keys[i], c.vals[i] = code, n
+			return
+		}
+	}
+	if c.more == nil {
+		c.more = make(map[string]int)
+	}
+	c.more[code] += n
+}
+
+func (c *categoryCounts) get(code string) int {
+	for i, k := range c.keys {
+		if k == code {
+			return c.vals[i]
+		}
+	}
+	return c.more[code]
+}
+
+func (c *categoryCounts) toMap() map[string]int {
+	out := make(map[string]int, len(c.keys)+len(c.more))
+	for i, k := range c.keys {
+		if k != "" {
+			out[k] = c.vals[i]
+		}
+	}
+	for k, v := range c.more {
+		out[k] = v
+	}
+	return out
+}
+
+func (c *categoryCounts) reset() {
+	more := c.more
+	clear(more)
+	*c = categoryCounts{more: more}
+}
+
+func categoryOf(err error) string {
+	if code := CodeOf(err); code != "" {
+		return code
+	}
+	return CategoryOther
+}
+
+// Reset clears all state for reuse. Aggregates previously returned by Err()
+// are unaffected.
 func (gd *Guard) Reset() {
+	gd = gd.root()
 	gd.lock()
-	gd.e0, gd.e1, gd.e2, gd.e3 = nil, nil, nil, nil
-	gd.more = nil
+	gd.e0 = nil
+	if gd.agg != nil && !gd.sealed {
+		more := gd.agg.more[:0]
+		clear(gd.agg.more)
+		*gd.agg = multiError{more: more}
+	} else {
+		gd.agg = nil
+	}
+	gd.sealed = false
+	gd.sortedAgg = nil
+	gd.base = forkBase{}
 	gd.n = 0
 	gd.checks, gd.failures, gd.dropped = 0, 0, 0
+	clear(gd.warnings)
+	gd.warnings = gd.warnings[:0]
+	gd.timings = gd.timings[:0]
+	clear(gd.seen)
+	gd.byCode.reset()
+	gd.keptCode.reset()
 	gd.unlock()
 }
 
 // Ok reports whether no error has been recorded.
 func (gd *Guard) Ok() bool {
+	gd = gd.root()
 	gd.lock()
 	ok := gd.n == 0
 	gd.unlock()
@@ -100,6 +279,7 @@ func (gd *Guard) Ok() bool {
 
 // ReachedCap reports whether the guard is at its configured cap (max > 0 && n >= max).
 func (gd *Guard) ReachedCap() bool {
+	gd = gd.root()
 	gd.lock()
 	reached := gd.max > 0 && gd.n >= gd.max
 	gd.unlock()
@@ -108,62 +288,164 @@ func (gd *Guard) ReachedCap() bool {
 
 // Add records err if non-nil; respects cap (max).
 func (gd *Guard) Add(err error) {
+	gd.AddKeep(err)
+}
+
+// AddKeep is like Add, but returns whether the error was kept (not dropped).
+// If err == nil, it returns true.
+func (gd *Guard) AddKeep(err error) bool {
 	if err == nil {
-		return
+		return true
+	}
+	if gd.root().flatten && isGroup(err) {
+		return gd.merge(err)
+	}
+	err = gd.decorate(err)
+	if gd.parent != nil {
+		return gd.parent.AddKeep(err)
 	}
 	gd.lock()
-	gd.failures++
-	if gd.max > 0 && gd.n >= gd.max {
-		gd.dropped++
-		gd.unlock()
-		return
+	kept := gd.addLocked(err)
+	gd.unlock()
+	if gd.onError != nil {
+		gd.onError(err)
 	}
-	switch gd.n {
-	case 0:
-		gd.e0 = err
-	case 1:
-		gd.e1 = err
-	case 2:
-		gd.e2 = err
-	case 3:
-		gd.e3 = err
+	return kept
+}
+
+// Merge records err like Add, but folds an aggregate (e.g. another Guard's
+// Err()) in member by member, so each one is prefixed and counted against the
+// cap. Errors the source dropped are carried over as dropped here. An
+// errors.Join result (any error with Unwrap() []error) is folded in the same
+// way.
+func (gd *Guard) Merge(err error) {
+	gd.merge(err)
+}
+
+// merge implements Merge and reports whether every member was kept.
+func (gd *Guard) merge(err error) bool {
+	switch e := err.(type) {
+	case ErrorGroup:
+		kept := true
+		e.Iter(func(m error) bool {
+			if c, clamped := m.(ErrorsClampedError); clamped {
+				gd.addDropped(c.Dropped)
+				return true
+			}
+			kept = gd.merge(m) && kept
+			return true
+		})
+		return kept
+	case interface{ Unwrap() []error }:
+		kept := true
+		for _, m := range e.Unwrap() {
+			kept = gd.merge(m) && kept
+		}
+		return kept
 	default:
-		gd.more = append(gd.more, err)
+		return gd.AddKeep(err)
 	}
-	gd.n++
-	gd.unlock()
 }
 
-// AddKeep is like Add, but returns whether the error was kept (not dropped).
-// If err == nil, it returns true.
-func (gd *Guard) AddKeep(err error) bool {
-	if err == nil {
+// isGroup reports whether Merge would fold err in member by member.
+func isGroup(err error) bool {
+	switch err.(type) {
+	case ErrorGroup, interface{ Unwrap() []error }:
 		return true
 	}
-	gd.lock()
+	return false
+}
+
+// addDropped counts n errors that were dropped before reaching this Guard.
+func (gd *Guard) addDropped(n int) {
+	r := gd.root()
+	r.lock()
+	r.failures += n
+	r.countCategoryLocked(CodeErrorsClamped, n)
+	r.dropped += n
+	r.mutableAggLocked().dropped = r.dropped
+	r.unlock()
+}
+
+// decorate applies this Guard's check label, field prefix and redaction to err.
+func (gd *Guard) decorate(err error) error {
+	if gd.label != "" {
+		err = labeled(gd.label, err)
+	}
+	if gd.prefix != "" {
+		err = WithPrefix(gd.prefix, err)
+	}
+	if gd.redact {
+		err = renderWith(err, RenderOptions{Redact: true})
+	}
+	return err
+}
+
+// addLocked records a non-nil err while the lock is held and reports whether it was kept.
+func (gd *Guard) addLocked(err error) bool {
+	cat := categoryOf(err)
 	gd.failures++
-	if gd.max > 0 && gd.n >= gd.max {
+	gd.countCategoryLocked(cat, 1)
+	if gd.dedup {
+		if key, ok := dedupKeyOf(err); ok {
+			if i, seen := gd.seen[key]; seen {
+				gd.bumpLocked(i)
+				return true
+			}
+			if !gd.capReachedLocked(cat) {
+				if gd.seen == nil {
+					gd.seen = make(map[dedupKey]int)
+				}
+				gd.seen[key] = gd.n
+			}
+		}
+	}
+	if gd.capReachedLocked(cat) {
 		gd.dropped++
-		gd.unlock()
+		gd.mutableAggLocked().dropped = gd.dropped
 		return false
 	}
-	switch gd.n {
-	case 0:
+	if gd.callers {
+		err = withCaller(err)
+	}
+	if gd.n == 0 && gd.dropped == 0 && gd.agg == nil {
 		gd.e0 = err
-	case 1:
-		gd.e1 = err
-	case 2:
-		gd.e2 = err
-	case 3:
-		gd.e3 = err
-	default:
-		gd.more = append(gd.more, err)
+	} else {
+		gd.mutableAggLocked().push(err)
 	}
 	gd.n++
-	gd.unlock()
+	if gd.maxPerCat > 0 {
+		gd.keptCode.add(cat, 1)
+	}
 	return true
 }
 
+// capReachedLocked reports whether an error of category cat would be dropped,
+// by the global cap or the per-category one.
+func (gd *Guard) capReachedLocked(cat string) bool {
+	if gd.max > 0 && gd.n >= gd.max {
+		return true
+	}
+	return gd.maxPerCat > 0 && gd.keptCode.get(cat) >= gd.maxPerCat
+}
+
+// mutableAggLocked returns an aggregate that may be written: it is allocated
+// on first use (adopting the inline e0) and copied if Err() handed it out.
+func (gd *Guard) mutableAggLocked() *multiError {
+	switch {
+	case gd.agg == nil:
+		gd.agg = &multiError{}
+		if gd.e0 != nil {
+			gd.agg.push(gd.e0)
+			gd.e0 = nil
+		}
+	case gd.sealed:
+		gd.agg = gd.agg.clone(1)
+		gd.sealed = false
+	}
+	return gd.agg
+}
+
 // Check is a convenience alias for Add.
 func (gd *Guard) Check(err error) {
 	if err == nil {
@@ -189,17 +471,13 @@ func (gd *Guard) CheckLazy(makeErr func() error) {
 	if makeErr == nil {
 		return
 	}
-	gd.lock()
-	if gd.max > 0 && gd.n >= gd.max {
-		gd.unlock()
+	if !gd.beginCheck() {
 		return
 	}
-	gd.checks++
-	gd.unlock()
-
-	if err := makeErr(); err != nil {
-		gd.Add(err)
-	}
+	start := gd.checkStart()
+	err := makeErr()
+	gd.recordTiming(start, err)
+	gd.Add(err)
 }
 
 // AddCheck increments Checks and evaluates f unless cap reached.
@@ -207,26 +485,31 @@ func (gd *Guard) AddCheck(f Check) {
 	if f == nil {
 		return
 	}
-	gd.lock()
-	if gd.max > 0 && gd.n >= gd.max {
-		gd.unlock()
+	if !gd.beginCheck() {
 		return
 	}
-	gd.checks++
-	gd.unlock()
+	start := gd.checkStart()
+	err := f()
+	gd.recordTiming(start, err)
+	gd.Add(err)
+}
 
-	if err := f(); err != nil {
-		gd.Add(err)
+// beginCheck counts a check about to be evaluated; false if the cap is reached.
+func (gd *Guard) beginCheck() bool {
+	r := gd.root()
+	r.lock()
+	defer r.unlock()
+	if r.max > 0 && r.n >= r.max {
+		return false
 	}
+	r.checks++
+	return true
 }
 
 // Run evaluates checks in order, stopping once cap is reached.
 func (gd *Guard) Run(checks ...Check) {
 	for _, f := range checks {
-		gd.lock()
-		reached := gd.max > 0 && gd.n >= gd.max
-		gd.unlock()
-		if reached {
+		if gd.ReachedCap() {
 			return
 		}
 		gd.AddCheck(f)
@@ -247,80 +530,46 @@ func (e ErrorsClampedError) Error() string {
 }
 
 // Err returns nil, a single error, or an aggregate snapshot.
-// It never mutates internal storage; when a sentinel is needed or
-// in thread-safe mode, it returns a copy/snapshot.
+// It never allocates: the aggregate is built while errors are recorded and is
+// handed out as-is; later Add/Reset calls copy it first, so a returned error
+// never changes. When errors were dropped the aggregate ends with an
+// ErrorsClampedError sentinel.
 func (gd *Guard) Err() error {
+	gd = gd.root()
 	gd.lock()
-	switch gd.n {
-	case 0:
-		gd.unlock()
-		return nil
-	case 1:
-		e0, dropped := gd.e0, gd.dropped
-		gd.unlock()
-		if e0 == nil {
+	defer gd.unlock()
+	if gd.agg == nil {
+		if gd.e0 == nil {
 			return nil
 		}
-		if dropped == 0 {
-			return e0
-		}
-		return multiError{e0: e0, more: []error{
-			ErrorsClampedError{Kept: 1, Dropped: dropped},
-		}}
-	default:
-		e0, e1, e2, e3, more, dropped := gd.snapshotErrorsLocked()
-		gd.unlock()
-		if dropped > 0 {
-			kept := countNonNil4(e0, e1, e2, e3) + len(more)
-			more = append(more, ErrorsClampedError{Kept: kept, Dropped: dropped})
-		}
-		return multiError{e0: e0, e1: e1, e2: e2, e3: e3, more: more}
-	}
-}
-
-// snapshotErrorsLocked returns copies when needed while the lock is held.
-// The returned 'more' slice is safe for the caller to append to.
-func (gd *Guard) snapshotErrorsLocked() (error, error, error, error, []error, int) {
-	e0, e1, e2, e3 := gd.e0, gd.e1, gd.e2, gd.e3
-	m, dropped := gd.more, gd.dropped
-
-	ratio := gd.compactRatio
-	if ratio <= 0 {
-		ratio = 2
-	}
-	threadsafe := gd.mu != nil
-	needCopy := threadsafe || dropped > 0 ||
-		(len(m) > 0 && cap(m) > ratio*len(m))
-
-	if !needCopy {
-		return e0, e1, e2, e3, m, dropped
+		return gd.e0
 	}
-
-	want := len(m)
-	if dropped > 0 {
-		want++ // room for sentinel
+	if gd.agg.dropped == 0 { // a buffer kept by Reset may hold none or one
+		switch gd.agg.n {
+		case 0:
+			return nil
+		case 1:
+			return gd.agg.e0
+		}
 	}
-	out := make([]error, 0, want)
-	if len(m) > 0 {
-		out = append(out, m...)
+	gd.sealLocked()
+	if gd.sorted {
+		return gd.sortedAgg
 	}
-	return e0, e1, e2, e3, out, dropped
+	return gd.agg
 }
 
-func countNonNil4(a, b, c, d error) (n int) {
-	if a != nil {
-		n++
-	}
-	if b != nil {
-		n++
-	}
-	if c != nil {
-		n++
+// sealLocked finalizes agg before it is shared; later writes copy it first.
+func (gd *Guard) sealLocked() {
+	if gd.agg == nil || gd.sealed {
+		return
 	}
-	if d != nil {
-		n++
+	gd.agg.compact(gd.compactRatio)
+	gd.agg.limit = gd.msgLimit
+	gd.sealed = true
+	if gd.sorted {
+		gd.sortedAgg = gd.agg.sortedByField()
 	}
-	return
 }
 
 // ----- Group error: iterator + Is/As + Unwrap -----
@@ -329,35 +578,103 @@ func countNonNil4(a, b, c, d error) (n int) {
 type ErrorGroup interface {
 	error
 	Iter(func(error) bool) // return false to stop early
+	All() iter.Seq[error]  // range-over-func form of Iter
 }
 
 type multiError struct {
 	e0, e1, e2, e3 error
-	more           []error // immutable or safely copied
+	more           []error
+	n              int // kept members (e0..e3 + more)
+	dropped        int // > 0 => trailing ErrorsClampedError sentinel
+	limit          int // Error() length cap; 0 => unlimited
 }
 
-func (m multiError) Error() string { return "multiple errors" }
+// push appends a member; the receiver must not have been handed out.
+func (m *multiError) push(err error) {
+	switch m.n {
+	case 0:
+		m.e0 = err
+	case 1:
+		m.e1 = err
+	case 2:
+		m.e2 = err
+	case 3:
+		m.e3 = err
+	default:
+		m.more = append(m.more, err)
+	}
+	m.n++
+}
 
-// Len reports number of underlying errors (SSO + more).
-func (m multiError) Len() int {
-	n := 0
-	if m.e0 != nil {
-		n++
+// at returns the i-th kept member.
+func (m *multiError) at(i int) error {
+	switch i {
+	case 0:
+		return m.e0
+	case 1:
+		return m.e1
+	case 2:
+		return m.e2
+	case 3:
+		return m.e3
 	}
-	if m.e1 != nil {
-		n++
+	return m.more[i-4]
+}
+
+// set replaces the i-th kept member; the receiver must not have been handed out.
+func (m *multiError) set(i int, err error) {
+	switch i {
+	case 0:
+		m.e0 = err
+	case 1:
+		m.e1 = err
+	case 2:
+		m.e2 = err
+	case 3:
+		m.e3 = err
+	default:
+		m.more[i-4] = err
 	}
-	if m.e2 != nil {
-		n++
+}
+
+// clone returns a private copy with room for extra appended 'more' entries.
+func (m *multiError) clone(extra int) *multiError {
+	c := *m
+	if len(m.more) > 0 {
+		c.more = make([]error, len(m.more), len(m.more)+extra)
+		copy(c.more, m.more)
 	}
-	if m.e3 != nil {
-		n++
+	return &c
+}
+
+// compact drops spare 'more' capacity beyond ratio*len (ratio <= 0 => 2).
+func (m *multiError) compact(ratio int) {
+	if ratio <= 0 {
+		ratio = 2
+	}
+	if len(m.more) > 0 && cap(m.more) > ratio*len(m.more) {
+		m.more = append([]error(nil), m.more...)
+	}
+}
+
+// clamped returns the trailing sentinel, if errors were dropped.
+func (m *multiError) clamped() (error, bool) {
+	if m.dropped == 0 {
+		return nil, false
+	}
+	return ErrorsClampedError{Kept: m.n, Dropped: m.dropped}, true
+}
+
+// Len reports number of underlying errors (SSO + more + clamp sentinel).
+func (m *multiError) Len() int {
+	if m.dropped > 0 {
+		return m.n + 1
 	}
-	return n + len(m.more)
+	return m.n
 }
 
-// Iter visits SSO (e0..e3) then all entries in 'more' in order.
-func (m multiError) Iter(fn func(error) bool) {
+// Iter visits SSO (e0..e3), then all entries in 'more', then the clamp sentinel.
+func (m *multiError) Iter(fn func(error) bool) {
 	if m.e0 != nil && !fn(m.e0) {
 		return
 	}
@@ -375,10 +692,16 @@ func (m multiError) Iter(fn func(error) bool) {
 			return
 		}
 	}
+	if c, ok := m.clamped(); ok {
+		fn(c)
+	}
 }
 
+// All returns an iterator over the same members as Iter.
+func (m *multiError) All() iter.Seq[error] { return m.Iter }
+
 // Is scans members; zero-alloc.
-func (m multiError) Is(target error) bool {
+func (m *multiError) Is(target error) bool {
 	if target == nil {
 		return false
 	}
@@ -399,11 +722,11 @@ func (m multiError) Is(target error) bool {
 			return true
 		}
 	}
-	return false
+	return m.dropped > 0 && target == ErrClamped
 }
 
-// As scans members; zero-alloc.
-func (m multiError) As(target any) bool {
+// As scans members; zero-alloc unless the clamp sentinel is the match.
+func (m *multiError) As(target any) bool {
 	if target == nil {
 		return false
 	}
@@ -424,38 +747,19 @@ func (m multiError) As(target any) bool {
 			return true
 		}
 	}
+	if c, ok := m.clamped(); ok {
+		return errors.As(c, target)
+	}
 	return false
 }
 
 // Unwrap allocates a flat []error for interop with code expecting slices.
 // errors.Is/As won't use this because multiError implements Is/As.
-func (m multiError) Unwrap() []error {
-	n := 0
-	if m.e0 != nil {
-		n++
-	}
-	if m.e1 != nil {
-		n++
-	}
-	if m.e2 != nil {
-		n++
-	}
-	if m.e3 != nil {
-		n++
-	}
-	out := make([]error, 0, n+len(m.more))
-	if m.e0 != nil {
-		out = append(out, m.e0)
-	}
-	if m.e1 != nil {
-		out = append(out, m.e1)
-	}
-	if m.e2 != nil {
-		out = append(out, m.e2)
-	}
-	if m.e3 != nil {
-		out = append(out, m.e3)
-	}
-	out = append(out, m.more...)
+func (m *multiError) Unwrap() []error {
+	out := make([]error, 0, m.Len())
+	m.Iter(func(e error) bool {
+		out = append(out, e)
+		return true
+	})
 	return out
 }
@@ -0,0 +1,70 @@
+package sanity
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// WithCallerInfo is a debug option that records the file:line of the call
+// that handed each kept error to the Guard (the Add, Check, Merge, ... call
+// site, or the nearest caller outside this package) and wraps the error in a
+// CallerError, so failures fed into one Guard by many packages can be traced.
+// Dropped errors are not wrapped. It costs a stack walk per kept error; leave
+// it off in production.
+func WithCallerInfo() GuardOption {
+	return func(g *Guard) { g.callers = true }
+}
+
+// CallerError is a kept error recorded by a Guard WithCallerInfo. Its message
+// is that of Err, which it unwraps to.
+type CallerError struct {
+	Err  error
+	File string
+	Line int
+}
+
+func (e CallerError) Error() string { return e.Err.Error() }
+func (e CallerError) Unwrap() error { return e.Err }
+
+// Caller returns the recording call site as "file:line".
+func (e CallerError) Caller() string { return e.File + ":" + strconv.Itoa(e.Line) }
+
+// FieldName reports the field of the wrapped error.
+func (e CallerError) FieldName() string {
+	var fe FieldError
+	if errors.As(e.Err, &fe) {
+		return fe.FieldName()
+	}
+	return ""
+}
+
+func (e CallerError) message(redact bool) string {
+	if m, ok := e.Err.(messager); ok {
+		return m.message(redact)
+	}
+	return strings.TrimPrefix(e.Err.Error(), e.FieldName()+": ")
+}
+
+const pkgPrefix = "github.com/sessaidi/sanity."
+
+// withCaller wraps err in a CallerError for the innermost frame outside this
+// package. Errors already wrapped (e.g. by an adopted fork) keep their call
+// site; without such a frame (a RunParallel worker) err is returned as is.
+func withCaller(err error) error {
+	if _, ok := err.(CallerError); ok {
+		return err
+	}
+	var pcs [32]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		f, more := frames.Next()
+		if f.Function != "" && !strings.HasPrefix(f.Function, pkgPrefix) && !strings.HasPrefix(f.Function, "runtime.") {
+			return CallerError{Err: err, File: f.File, Line: f.Line}
+		}
+		if !more {
+			return err
+		}
+	}
+}
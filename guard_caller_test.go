@@ -0,0 +1,77 @@
+package sanity_test
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+// callerLine returns the line of err's recorded call site relative to base,
+// or -1 if err has none in this file.
+func callerLine(err error, base int) int {
+	var ce sanity.CallerError
+	if !errors.As(err, &ce) || filepath.Base(ce.File) != "guard_caller_test.go" {
+		return -1
+	}
+	return ce.Line - base
+}
+
+func TestGuardCallerInfo(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Kept errors record the call site",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithCallerInfo())
+				_, _, base, _ := runtime.Caller(0)
+				g.Check(sanity.NonZero("port", 0))
+				g.Scope("db").AddCheck(func() error { return sanity.NonEmpty("host", "") })
+				var lines []int
+				for e := range sanity.Errors(g.Err()) {
+					lines = append(lines, callerLine(e, base))
+				}
+				return []interface{}{lines, fieldsOf(g.Err())}
+			},
+			expected: []interface{}{[]int{1, 2}, []string{"port", "db.host"}},
+		},
+		{
+			name: "Message, field and category are unchanged",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithCallerInfo())
+				g.Add(sanity.NonZero("port", 0))
+				err := sanity.WithPrefix("srv", g.Err())
+				var ce sanity.CallerError
+				return []interface{}{err.Error(), errors.Is(err, sanity.ErrNonZero), sanity.CodeOf(err), errors.As(err, &ce)}
+			},
+			expected: []interface{}{"srv.port: must be non-zero", true, "NON_ZERO", true},
+		},
+		{
+			name: "Dropped errors and guards without the option are not wrapped",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithCallerInfo())
+				g.Add(errors.New("first"))
+				g.Add(sanity.NonZero("b", 0))
+				plain := sanity.NewGuard()
+				plain.Add(sanity.NonZero("c", 0))
+				var ce sanity.CallerError
+				return []interface{}{g.Stats().Dropped, errors.As(plain.Err(), &ce)}
+			},
+			expected: []interface{}{1, false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrAborted indicates checks were cut short by context cancellation or deadline.
+var ErrAborted = errors.New("sanity:aborted")
+
+// AbortedError records why a run was aborted. It matches ErrAborted via
+// errors.Is and unwraps to the context error (context.Canceled or
+// context.DeadlineExceeded, possibly wrapped by the check).
+type AbortedError struct {
+	Err error
+}
+
+func (e AbortedError) Error() string        { return "aborted: " + e.Err.Error() }
+func (e AbortedError) Is(target error) bool { return target == ErrAborted }
+func (e AbortedError) Unwrap() error        { return e.Err }
+func (e AbortedError) Code() string         { return CodeAborted }
+
+// CheckCtx is a check that honors cancellation and deadlines.
+type CheckCtx func(ctx context.Context) error
+
+// RunCtx evaluates checks in order, stopping once the cap is reached or ctx is
+// done. Cancellation is recorded once as an AbortedError rather than as a
+// regular failure, whether observed between checks or returned by one.
+func (gd *Guard) RunCtx(ctx context.Context, checks ...CheckCtx) {
+	for _, f := range checks {
+		if gd.ReachedCap() {
+			return
+		}
+		if err := ctx.Err(); err != nil {
+			gd.Add(AbortedError{Err: err})
+			return
+		}
+		if f == nil || !gd.beginCheck() {
+			continue
+		}
+		start := gd.checkStart()
+		err := f(ctx)
+		gd.recordTiming(start, err)
+		if err != nil && ctx.Err() != nil && isContextErr(err) {
+			gd.Add(AbortedError{Err: err})
+			return
+		}
+		gd.Add(err)
+	}
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,109 @@
+package sanity_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestGuardRunCtx(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Runs checks with the context",
+			function: func() interface{} {
+				type key struct{}
+				ctx := context.WithValue(context.Background(), key{}, "v")
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.RunCtx(ctx,
+					func(ctx context.Context) error { return sanity.NonEmpty("k", fmt.Sprint(ctx.Value(key{}))) },
+					nil,
+					func(context.Context) error { return sanity.NonZero("n", 0) },
+				)
+				return []interface{}{g.Stats(), fieldOf(g.Err())}
+			},
+			expected: []interface{}{sanity.MGStats{Checks: 2, Failures: 1, Kept: 1}, "n"},
+		},
+		{
+			name: "Cancellation between checks is recorded as ErrAborted",
+			function: func() interface{} {
+				ctx, cancel := context.WithCancel(context.Background())
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.RunCtx(ctx,
+					func(context.Context) error { cancel(); return nil },
+					func(context.Context) error { return sanity.NonZero("n", 0) },
+				)
+				err := g.Err()
+				return []interface{}{g.Stats().Checks, errors.Is(err, sanity.ErrAborted), errors.Is(err, context.Canceled), sanity.CodeOf(err)}
+			},
+			expected: []interface{}{1, true, true, "ABORTED"},
+		},
+		{
+			name: "Deadline returned by a check is ErrAborted, not a failure of its own",
+			function: func() interface{} {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+				defer cancel()
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.RunCtx(ctx,
+					func(ctx context.Context) error {
+						<-ctx.Done()
+						return fmt.Errorf("probe: %w", ctx.Err())
+					},
+					func(context.Context) error { return nil },
+				)
+				err := g.Err()
+				return []interface{}{
+					g.Stats().Kept, errors.Is(err, sanity.ErrAborted), errors.Is(err, context.DeadlineExceeded),
+					err.Error(), sanity.StatusFor(err),
+				}
+			},
+			expected: []interface{}{1, true, true, "aborted: probe: context deadline exceeded", 503},
+		},
+		{
+			name: "Context errors from a live context are ordinary failures",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.RunCtx(context.Background(), func(context.Context) error { return context.DeadlineExceeded })
+				return errors.Is(g.Err(), sanity.ErrAborted)
+			},
+			expected: false,
+		},
+		{
+			name: "Stops at the cap",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				fail := func(context.Context) error { return sanity.NonZero("n", 0) }
+				g.RunCtx(context.Background(), fail, fail)
+				return g.Stats()
+			},
+			expected: sanity.MGStats{Checks: 1, Failures: 1, Kept: 1},
+		},
+		{
+			name: "RunParallel records cancellation as ErrAborted",
+			function: func() interface{} {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				g := sanity.NewGuard()
+				g.RunParallel(ctx, func() error { return nil })
+				return errors.Is(g.Err(), sanity.ErrAborted)
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
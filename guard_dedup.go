@@ -0,0 +1,106 @@
+package sanity
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// WithDedup collapses repeated failures with the same field and category
+// (error code) into the first kept error, wrapped in a RepeatedError carrying
+// the occurrence count. Index segments are ignored when comparing fields, so
+// "items[3].name" and "items[7].name" collapse too. Collapsed repeats count as
+// failures but neither as kept nor dropped.
+func WithDedup() GuardOption {
+	return func(g *Guard) { g.dedup = true }
+}
+
+// RepeatedError is a kept error that occurred Count times (see WithDedup).
+// It unwraps to the first occurrence.
+type RepeatedError struct {
+	Err   error
+	Count int
+}
+
+func (e RepeatedError) Error() string { return e.Err.Error() + e.suffix() }
+func (e RepeatedError) Unwrap() error { return e.Err }
+
+// FieldName reports the field of the first occurrence.
+func (e RepeatedError) FieldName() string {
+	var fe FieldError
+	if errors.As(e.Err, &fe) {
+		return fe.FieldName()
+	}
+	return ""
+}
+
+func (e RepeatedError) message(redact bool) string {
+	if m, ok := e.Err.(messager); ok {
+		return m.message(redact) + e.suffix()
+	}
+	return strings.TrimPrefix(e.Err.Error(), e.FieldName()+": ") + e.suffix()
+}
+
+func (e RepeatedError) suffix() string { return " (x" + strconv.Itoa(e.Count) + ")" }
+
+type dedupKey struct {
+	field string // index segments blanked
+	code  string
+}
+
+// dedupKeyOf reports the dedup key of err; errors without a field or code are
+// never collapsed.
+func dedupKeyOf(err error) (dedupKey, bool) {
+	var fe FieldError
+	if !errors.As(err, &fe) {
+		return dedupKey{}, false
+	}
+	code := CodeOf(err)
+	if code == "" {
+		return dedupKey{}, false
+	}
+	return dedupKey{field: stripIndexes(fe.FieldName()), code: code}, true
+}
+
+// stripIndexes turns "a[3].b[12]" into "a[].b[]".
+func stripIndexes(path string) string {
+	if strings.IndexByte(path, '[') < 0 {
+		return path
+	}
+	var b strings.Builder
+	b.Grow(len(path))
+	in := false
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case c == '[':
+			in = true
+		case c == ']':
+			in = false
+		case in:
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// bumpLocked counts one more occurrence of the i-th kept error.
+func (gd *Guard) bumpLocked(i int) {
+	var cur error
+	if gd.agg == nil {
+		cur = gd.e0
+	} else {
+		cur = gd.mutableAggLocked().at(i)
+	}
+	r, ok := cur.(RepeatedError)
+	if !ok {
+		r = RepeatedError{Err: cur, Count: 1}
+	}
+	r.Count++
+	if gd.agg == nil {
+		gd.e0 = r
+	} else {
+		gd.agg.set(i, r)
+	}
+}
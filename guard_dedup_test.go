@@ -0,0 +1,110 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestGuardDedup(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Repeats collapse into the first occurrence with a count",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithDedup())
+				for i := 0; i < 3; i++ {
+					g.Add(sanity.NonEmpty("name", ""))
+				}
+				g.Add(sanity.NonZero("name", 0))   // other category
+				g.Add(sanity.NonEmpty("mode", "")) // other field
+				var msgs []string
+				for _, e := range sanity.GroupAsSlice(g.Err(), nil) {
+					msgs = append(msgs, e.Error())
+				}
+				return []interface{}{msgs, g.Stats()}
+			},
+			expected: []interface{}{
+				[]string{"name: must be non-empty (x3)", "name: must be non-zero", "mode: must be non-empty"},
+				sanity.MGStats{Failures: 5, Kept: 3},
+			},
+		},
+		{
+			name: "RepeatedError keeps the original reachable",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithDedup())
+				g.Add(sanity.InRangeNum("port", 0, 1, 10))
+				g.Add(sanity.InRangeNum("port", 99, 1, 10))
+				err := g.Err()
+				var rep sanity.RepeatedError
+				var re sanity.RangeError
+				return []interface{}{
+					errors.As(err, &rep) && rep.Count == 2, errors.As(err, &re) && re.Value() == 0,
+					errors.Is(err, sanity.ErrOutOfRange), sanity.CodeOf(err), fieldOf(err),
+				}
+			},
+			expected: []interface{}{true, true, true, "OUT_OF_RANGE", "port"},
+		},
+		{
+			name: "Index segments are ignored; first path is kept",
+			function: func() interface{} {
+				type rec struct{ Name string }
+				recs := make([]rec, 1000)
+				err := sanity.ValidateSlice("items", recs, func(i int, r rec, g *sanity.Guard) {
+					g.Check(sanity.NonEmpty("name", r.Name))
+				}, sanity.WithDedup())
+				return []interface{}{sanity.GroupAsSlice(err, nil)[0].Error(), len(sanity.GroupAsSlice(err, nil))}
+			},
+			expected: []interface{}{"items[0].name: must be non-empty (x1000)", 1},
+		},
+		{
+			name: "Repeats of a kept error are collapsed even at the cap",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(1), sanity.WithDedup())
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonEmpty("b", "")) // dropped
+				g.Add(sanity.NonEmpty("a", "")) // collapsed
+				return []interface{}{g.Stats(), sanity.GroupAsSlice(g.Err(), nil)[0].Error()}
+			},
+			expected: []interface{}{sanity.MGStats{Failures: 3, Kept: 1, Dropped: 1}, "a: must be non-empty (x2)"},
+		},
+		{
+			name: "Snapshots are not changed by later repeats",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithDedup())
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonZero("b", 0))
+				snap := g.Err()
+				g.Add(sanity.NonEmpty("a", ""))
+				return []string{sanity.GroupAsSlice(snap, nil)[0].Error(), sanity.GroupAsSlice(g.Err(), nil)[0].Error()}
+			},
+			expected: []string{"a: must be non-empty", "a: must be non-empty (x2)"},
+		},
+		{
+			name: "Reset forgets seen keys; foreign errors are never collapsed",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithDedup())
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Reset()
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(errors.New("x"))
+				g.Add(errors.New("x"))
+				return g.Stats().Kept
+			},
+			expected: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package sanity
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// forkBase records how much state a forked Guard inherited, so Adopt can
+// tell it apart from what was recorded afterwards.
+type forkBase struct {
+	kept, dropped, checks, warnings, timings int
+}
+
+// Fork returns an independent copy of gd's state (errors, stats, warnings and
+// options), for speculative checks: record into the fork, then Adopt it if the
+// section it validates turns out to be enabled, or drop it. The copy is cheap;
+// the aggregate is shared until either side writes to it.
+//
+// The fork is a root Guard without gd's WithOnError callback; Adopt reports the
+// adopted errors to gd's callback instead.
+//
+//	tls := g.Fork()
+//	tls.Check(sanity.NonBlank("tls.cert", cfg.TLS.Cert))
+//	if cfg.TLS.Enabled {
+//		g.Adopt(&tls)
+//	}
+func (gd *Guard) Fork() Guard {
+	r := gd.root()
+	r.lock()
+	defer r.unlock()
+	r.sealLocked()
+	f := *r
+	if r.mu != nil {
+		f.mu = &sync.Mutex{}
+	}
+	f.onError = nil
+	f.seen = maps.Clone(r.seen)
+	f.byCode.more = maps.Clone(r.byCode.more)
+	f.keptCode.more = maps.Clone(r.keptCode.more)
+	f.warnings = slices.Clone(r.warnings)
+	f.timings = slices.Clone(r.timings)
+	f.base = forkBase{
+		kept: r.n, dropped: r.dropped, checks: r.checks,
+		warnings: len(r.warnings), timings: len(r.timings),
+	}
+	return f
+}
+
+// Adopt records into gd what child recorded since it was forked (everything,
+// if child is not a fork): its new errors, subject to gd's cap and dedup, its
+// dropped errors, evaluated checks, warnings and timings. Errors are taken as child
+// decorated them, so gd's field prefix is not applied twice. Repeats that child
+// collapsed into an error it inherited are not carried over.
+func (gd *Guard) Adopt(child *Guard) {
+	if child == nil {
+		return
+	}
+	c := child.root()
+	c.lock()
+	var errs []error
+	for i := c.base.kept; i < c.n; i++ {
+		if c.agg == nil {
+			errs = append(errs, c.e0)
+		} else {
+			errs = append(errs, c.agg.at(i))
+		}
+	}
+	dropped := c.dropped - c.base.dropped
+	checks := c.checks - c.base.checks
+	warnings := slices.Clone(c.warnings[c.base.warnings:])
+	timings := slices.Clone(c.timings[c.base.timings:])
+	c.unlock()
+
+	r := gd.root()
+	for _, err := range errs {
+		r.lock()
+		r.addLocked(err)
+		r.unlock()
+		if r.onError != nil {
+			r.onError(err)
+		}
+	}
+	if dropped > 0 {
+		r.addDropped(dropped)
+	}
+	r.lock()
+	r.checks += checks
+	r.warnings = append(r.warnings, warnings...)
+	r.timings = append(r.timings, timings...)
+	r.unlock()
+}
@@ -0,0 +1,95 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestGuardFork(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Fork is independent in both directions",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonZero("a", 0))
+				f := g.Fork()
+				f.Add(sanity.NonZero("b", 0))
+				g.Add(sanity.NonZero("c", 0))
+				return [][]string{fieldsOf(g.Err()), fieldsOf(f.Err())}
+			},
+			expected: [][]string{{"a", "c"}, {"a", "b"}},
+		},
+		{
+			name: "Dropped fork leaves the parent untouched",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonZero("a", 0))
+				f := g.Fork()
+				f.AddCheck(func() error { return sanity.NonZero("tls.cert", "") })
+				return []interface{}{fieldsOf(g.Err()), g.Stats()}
+			},
+			expected: []interface{}{[]string{"a"}, sanity.MGStats{Failures: 1, Kept: 1}},
+		},
+		{
+			name: "Adopt commits only what the fork recorded",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithFieldPrefix("cfg"))
+				g.Add(sanity.NonZero("a", 0))
+				f := g.Fork()
+				f.AddCheck(func() error { return sanity.NonZero("tls.cert", "") })
+				f.AddWarning(errors.New("tls: self-signed"))
+				g.Add(sanity.NonZero("b", 0))
+				g.Adopt(&f)
+				return []interface{}{fieldsOf(g.Err()), g.Stats()}
+			},
+			expected: []interface{}{
+				[]string{"cfg.a", "cfg.b", "cfg.tls.cert"},
+				sanity.MGStats{Checks: 1, Failures: 3, Kept: 3, Warnings: 1},
+			},
+		},
+		{
+			name: "Adopted errors respect the parent's cap and callback",
+			function: func() interface{} {
+				var seen []string
+				g := sanity.NewGuard(sanity.WithMaxErrors(2), sanity.WithOnError(func(err error) {
+					seen = append(seen, fieldOf(err))
+				}))
+				g.Add(sanity.NonZero("a", 0))
+				f := g.Fork()
+				f.Add(sanity.NonZero("b", 0))
+				f.Add(sanity.NonZero("c", 0))
+				g.Adopt(&f)
+				return []interface{}{seen, g.Stats()}
+			},
+			expected: []interface{}{
+				[]string{"a", "b"},
+				sanity.MGStats{Failures: 3, Kept: 2, Dropped: 1},
+			},
+		},
+		{
+			name: "Fork of a scope forks the root; nil Adopt is a no-op",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Scope("s").Add(sanity.NonZero("a", 0))
+				f := g.Scope("s").Fork()
+				g.Adopt(nil)
+				return fieldsOf(f.Err())
+			},
+			expected: []string{"s.a"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package sanity
+
+import (
+	"errors"
+	"slices"
+)
+
+// CheckError labels an error that carries no field of its own with the name
+// of the check that produced it (see Guard.Named). It unwraps to Err.
+type CheckError struct {
+	Check string
+	Err   error
+}
+
+func (e CheckError) Error() string { return e.Check + ": " + e.Err.Error() }
+func (e CheckError) Unwrap() error { return e.Err }
+
+// Named returns a view of gd that labels recorded errors with the check name.
+// FieldErrors already say where they come from and are recorded unchanged;
+// anything else (e.g. a bare error from a third-party client) is wrapped in a
+// CheckError. Like Scope, the view shares storage, cap and stats with gd.
+func (gd *Guard) Named(label string) *Guard {
+	return &Guard{parent: gd, label: label}
+}
+
+// RunNamed evaluates checks in label order, each as Named(label), stopping
+// once the cap is reached.
+func (gd *Guard) RunNamed(checks map[string]Check) {
+	labels := make([]string, 0, len(checks))
+	for l := range checks {
+		labels = append(labels, l)
+	}
+	slices.Sort(labels)
+	for _, l := range labels {
+		if gd.ReachedCap() {
+			return
+		}
+		gd.Named(l).AddCheck(checks[l])
+	}
+}
+
+// labeled wraps err in a CheckError unless it already names a field.
+func labeled(label string, err error) error {
+	var fe FieldError
+	if errors.As(err, &fe) {
+		return err
+	}
+	return CheckError{Check: label, Err: err}
+}
@@ -0,0 +1,80 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+var errRefused = errors.New("connection refused")
+
+func TestGuardNamed(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Bare errors carry the check label",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.Named("db").Check(errRefused)
+				err := g.Err()
+				var ce sanity.CheckError
+				return []interface{}{err.Error(), errors.As(err, &ce) && ce.Check == "db", errors.Is(err, errRefused)}
+			},
+			expected: []interface{}{"db: connection refused", true, true},
+		},
+		{
+			name: "Field errors are recorded unchanged",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.Named("db").Check(sanity.NonZero("port", 0))
+				return g.Err().Error()
+			},
+			expected: "port: must be non-zero",
+		},
+		{
+			name: "Labels compose with scopes",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Scope("srv").Named("dns").Check(errRefused)
+				g.Scope("srv").Named("dns").Check(sanity.NonEmpty("host", ""))
+				var msgs []string
+				for _, e := range sanity.GroupAsSlice(g.Err(), nil) {
+					msgs = append(msgs, e.Error())
+				}
+				return msgs
+			},
+			expected: []string{"srv: dns: connection refused", "srv.host: must be non-empty"},
+		},
+		{
+			name: "RunNamed runs in label order and respects the cap",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(2))
+				g.RunNamed(map[string]sanity.Check{
+					"cache": func() error { return errRefused },
+					"auth":  func() error { return nil },
+					"db":    func() error { return errors.New("timeout") },
+					"queue": func() error { return errors.New("not evaluated") },
+				})
+				var msgs []string
+				for _, e := range sanity.GroupAsSlice(g.Err(), nil) {
+					msgs = append(msgs, e.Error())
+				}
+				return []interface{}{msgs, g.Stats().Checks}
+			},
+			expected: []interface{}{[]string{"cache: connection refused", "db: timeout"}, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
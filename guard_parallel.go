@@ -0,0 +1,84 @@
+package sanity
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// WithParallelism bounds the number of checks RunParallel evaluates at once.
+// n <= 0 defaults to GOMAXPROCS.
+func WithParallelism(n int) GuardOption {
+	return func(g *Guard) { g.parallelism = n }
+}
+
+// RunParallel evaluates checks concurrently on a bounded worker pool (see
+// WithParallelism) and returns once all started checks have finished.
+// Scheduling stops once the cap is reached or ctx is done; in the latter case
+// an AbortedError is recorded. Errors are recorded in completion order, and a
+// panicking check is recorded as a PanicError instead of crashing the process.
+//
+// The Guard need not be WithThreadSafe unless other goroutines use it too.
+func (gd *Guard) RunParallel(ctx context.Context, checks ...Check) {
+	workers := gd.root().parallelism
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	var (
+		mu      sync.Mutex // serializes Guard access from the workers
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, workers)
+		aborted bool
+	)
+	for _, f := range checks {
+		if f == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			aborted = true
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			aborted = true
+		}
+		if aborted {
+			break
+		}
+		mu.Lock()
+		ok := gd.beginCheck()
+		mu.Unlock()
+		if !ok {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			start := gd.checkStart()
+			err := CatchPanic(f)
+			timed := !start.IsZero()
+			var d time.Duration
+			if timed {
+				d = time.Since(start) // before locking, so waiting for mu is not counted
+			}
+			if err != nil || timed {
+				mu.Lock()
+				if timed {
+					gd.recordDuration(d, err)
+				}
+				gd.Add(err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if aborted {
+		gd.Add(AbortedError{Err: ctx.Err()})
+	}
+}
@@ -0,0 +1,113 @@
+package sanity_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestGuardRunParallel(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Runs every check and records failures",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.RunParallel(context.Background(),
+					func() error { return sanity.NonZero("a", 0) },
+					func() error { return nil },
+					func() error { return sanity.NonEmpty("b", "") },
+					nil,
+				)
+				err := g.Err()
+				return []interface{}{g.Stats(), errors.Is(err, sanity.ErrNonZero), errors.Is(err, sanity.ErrNonEmpty)}
+			},
+			expected: []interface{}{sanity.MGStats{Checks: 3, Failures: 2, Kept: 2}, true, true},
+		},
+		{
+			name: "Parallelism bounds concurrent checks",
+			function: func() interface{} {
+				var cur, peak atomic.Int32
+				check := func() error {
+					n := cur.Add(1)
+					for {
+						p := peak.Load()
+						if n <= p || peak.CompareAndSwap(p, n) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+					cur.Add(-1)
+					return nil
+				}
+				g := sanity.NewGuard(sanity.WithParallelism(2))
+				g.RunParallel(context.Background(), check, check, check, check, check, check)
+				return []interface{}{peak.Load() <= 2, g.Stats().Checks}
+			},
+			expected: []interface{}{true, 6},
+		},
+		{
+			name: "Stops scheduling once the cap is reached",
+			function: func() interface{} {
+				var evaluated atomic.Int32
+				fail := func() error {
+					evaluated.Add(1)
+					return sanity.NonZero("n", 0)
+				}
+				g := sanity.NewGuard(sanity.WithParallelism(1))
+				g.RunParallel(context.Background(), fail, fail, fail, fail)
+				return []interface{}{evaluated.Load(), g.Stats().Kept}
+			},
+			expected: []interface{}{int32(1), 1},
+		},
+		{
+			name: "Done context stops scheduling and is recorded",
+			function: func() interface{} {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				var evaluated atomic.Int32
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.RunParallel(ctx, func() error { evaluated.Add(1); return nil })
+				return []interface{}{evaluated.Load(), errors.Is(g.Err(), context.Canceled)}
+			},
+			expected: []interface{}{int32(0), true},
+		},
+		{
+			name: "Panicking check is recorded as PanicError",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.RunParallel(context.Background(), func() error { panic("boom") })
+				var pe sanity.PanicError
+				return errors.As(g.Err(), &pe) && pe.Value == "boom"
+			},
+			expected: true,
+		},
+		{
+			name: "Works through a scope",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Scope("db").RunParallel(context.Background(),
+					func() error { return sanity.NonZero("port", 0) },
+				)
+				return fieldOf(g.Err())
+			},
+			expected: "db.port",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
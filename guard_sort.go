@@ -0,0 +1,42 @@
+package sanity
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// WithSortedErrors makes Err() order the aggregate's members by field path
+// (byte order, stable for equal paths), independent of check order, e.g. for
+// golden files and alert deduplication. Members without a field come first;
+// the clamp sentinel stays last. Err() then allocates one sorted copy each
+// time new errors were recorded since the previous call.
+func WithSortedErrors() GuardOption {
+	return func(g *Guard) { g.sorted = true }
+}
+
+// sortedByField returns a copy of m with its kept members stably sorted by
+// field path.
+func (m *multiError) sortedByField() *multiError {
+	members := make([]error, m.n)
+	for i := range members {
+		members[i] = m.at(i)
+	}
+	slices.SortStableFunc(members, func(a, b error) int {
+		return strings.Compare(fieldPathOf(a), fieldPathOf(b))
+	})
+	c := m.clone(0)
+	for i, e := range members {
+		c.set(i, e)
+	}
+	return c
+}
+
+// fieldPathOf returns err's field path, or "" if it carries none.
+func fieldPathOf(err error) string {
+	var fe FieldError
+	if errors.As(err, &fe) {
+		return fe.FieldName()
+	}
+	return ""
+}
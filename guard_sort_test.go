@@ -0,0 +1,80 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestGuardSortedErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Members ordered by field, stable for equal fields",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithSortedErrors())
+				g.Add(sanity.NonZero("port", 0))
+				g.Add(sanity.NonEmpty("mode", ""))
+				g.Add(errors.New("disk full"))
+				g.Add(sanity.NonZero("mode", ""))
+				var msgs []string
+				for e := range sanity.Errors(g.Err()) {
+					msgs = append(msgs, e.Error())
+				}
+				return msgs
+			},
+			expected: []string{"disk full", "mode: must be non-empty", "mode: must be non-zero", "port: must be non-zero"},
+		},
+		{
+			name: "Clamp sentinel stays last",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(2), sanity.WithSortedErrors())
+				g.Add(sanity.NonZero("b", 0))
+				g.Add(sanity.NonZero("a", 0))
+				g.Add(sanity.NonZero("c", 0))
+				return fieldsOf(g.Err())
+			},
+			expected: []string{"a", "b", ""},
+		},
+		{
+			name: "Returned aggregates are snapshots; later adds re-sort",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithSortedErrors())
+				g.Add(sanity.NonZero("b", 0))
+				g.Add(sanity.NonZero("a", 0))
+				first := g.Err()
+				g.Add(sanity.NonZero("0", 0))
+				return [][]string{fieldsOf(first), fieldsOf(g.Err())}
+			},
+			expected: [][]string{{"a", "b"}, {"0", "a", "b"}},
+		},
+		{
+			name: "Dedup still collapses after a sorted Err()",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithSortedErrors(), sanity.WithDedup())
+				g.Add(sanity.NonZero("b", 0))
+				g.Add(sanity.NonZero("a", 0))
+				_ = g.Err()
+				g.Add(sanity.NonZero("b", 0))
+				var msgs []string
+				for e := range sanity.Errors(g.Err()) {
+					msgs = append(msgs, e.Error())
+				}
+				return msgs
+			},
+			expected: []string{"a: must be non-zero", "b: must be non-zero (x2)"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
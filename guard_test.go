@@ -207,6 +207,38 @@ func TestGuard(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "Err snapshot unaffected by later Add and Reset",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonZero("b", 0))
+				snap := g.Err()
+				g.Add(sanity.InRangeNum("c", -1, 0, 1))
+				n1, _ := sanity.GroupLen(snap)
+				n2, _ := sanity.GroupLen(g.Err())
+				g.Reset()
+				g.Add(sanity.NonEmpty("z", ""))
+				return []interface{}{n1, n2, errors.Is(snap, sanity.ErrOutOfRange), len(sanity.GroupAsSlice(snap, nil))}
+			},
+			expected: []interface{}{2, 3, false, 2},
+		},
+		{
+			name: "Clamp sentinel is last member and tracks later drops",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(1))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonZero("b", 0))
+				first := g.Err()
+				g.Add(sanity.NonZero("c", 0))
+				var ce1, ce2 sanity.ErrorsClampedError
+				members := sanity.GroupAsSlice(first, nil)
+				_ = errors.As(members[len(members)-1], &ce1)
+				_ = errors.As(g.Err(), &ce2)
+				return []int{len(members), ce1.Dropped, ce2.Dropped}
+			},
+			expected: []int{2, 1, 2},
+		},
 		{
 			name: "Duration validator quick sanity",
 			function: func() interface{} {
@@ -223,3 +255,402 @@ func TestGuard(t *testing.T) {
 		})
 	}
 }
+
+func TestGuardErrZeroAlloc(t *testing.T) {
+	e1 := sanity.NonEmpty("a", "")
+	e2 := sanity.NonZero("b", 0)
+	testCases := []struct {
+		name string
+		opts []sanity.GuardOption
+		adds int
+	}{
+		{name: "FirstError/1", adds: 1},
+		{name: "FirstError/Dropped", adds: 3},
+		{name: "Unlimited/SSO2", opts: []sanity.GuardOption{sanity.WithMaxErrors(0)}, adds: 2},
+		{name: "Unlimited/SSO4", opts: []sanity.GuardOption{sanity.WithMaxErrors(0)}, adds: 4},
+		{name: "Unlimited/More", opts: []sanity.GuardOption{sanity.WithMaxErrors(0)}, adds: 9},
+		{name: "ThreadSafe/1", opts: []sanity.GuardOption{sanity.WithThreadSafe()}, adds: 1},
+		{name: "ThreadSafe/SSO4", opts: []sanity.GuardOption{sanity.WithThreadSafe(), sanity.WithMaxErrors(0)}, adds: 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := sanity.NewGuard(tc.opts...)
+			for i := 0; i < tc.adds; i++ {
+				if i%2 == 0 {
+					g.Add(e1)
+				} else {
+					g.Add(e2)
+				}
+			}
+			allocs := testing.AllocsPerRun(100, func() { sinkErr = g.Err() })
+			assert.Equal(t, 0.0, allocs)
+		})
+	}
+}
+
+func TestGuardResetErr(t *testing.T) {
+	g := sanity.NewGuard(sanity.WithMaxErrors(0))
+	g.Add(sanity.NonEmpty("a", ""))
+	g.Add(sanity.NonZero("b", 0))
+	g.Reset()
+	assert.True(t, g.Ok())
+	assert.Nil(t, g.Err())
+	allocs := testing.AllocsPerRun(100, func() { sinkErr = g.Err() })
+	assert.Equal(t, 0.0, allocs)
+}
+
+func TestGuardScope(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Nested scopes prefix field paths",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				srv := g.Scope("server")
+				srv.Check(sanity.NonZero("port", 0))
+				tls := srv.Scope("tls")
+				tls.Check(sanity.NonBlank("cert", ""))
+				g.Check(sanity.NonEmpty("name", ""))
+				return fieldsOf(g.Err())
+			},
+			expected: []string{"server.port", "server.tls.cert", "name"},
+		},
+		{
+			name: "Scope shares cap, stats and Err with the root",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(1))
+				s := g.Scope("db")
+				s.Run(
+					func() error { return sanity.NonEmpty("dsn", "") },
+					func() error { return sanity.NonZero("pool", 0) }, // gated by the root cap
+				)
+				st := g.Stats()
+				return []interface{}{s.ReachedCap(), s.Ok(), st.Checks, st.Kept, fieldOf(s.Err())}
+			},
+			expected: []interface{}{true, false, 1, 1, "db.dsn"},
+		},
+		{
+			name: "WithFieldPrefix applies to the guard itself and its scopes",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithFieldPrefix("app"))
+				g.Check(sanity.NonZero("port", 0))
+				g.Scope("log").CheckLazy(func() error { return sanity.NonEmpty("level", "") })
+				return fieldsOf(g.Err())
+			},
+			expected: []string{"app.port", "app.log.level"},
+		},
+		{
+			name: "Foreign errors in a scope get a FieldPathError",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.Scope("cache").Add(errors.New("unreachable"))
+				var pe sanity.FieldPathError
+				ok := errors.As(g.Err(), &pe)
+				return []interface{}{ok, pe.Path}
+			},
+			expected: []interface{}{true, "cache"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestGuardMerge(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Members are folded in individually and prefixed",
+			function: func() interface{} {
+				sub := sanity.NewGuard(sanity.WithMaxErrors(0))
+				sub.Add(sanity.NonZero("port", 0))
+				sub.Add(sanity.NonEmpty("host", ""))
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("name", ""))
+				g.Scope("db").Merge(sub.Err())
+				g.Merge(nil)
+				return []interface{}{fieldsOf(g.Err()), g.Stats().Kept}
+			},
+			expected: []interface{}{[]string{"name", "db.port", "db.host"}, 3},
+		},
+		{
+			name: "Cap applies to merged members",
+			function: func() interface{} {
+				sub := sanity.NewGuard(sanity.WithMaxErrors(0))
+				sub.Add(sanity.NonZero("a", 0))
+				sub.Add(sanity.NonZero("b", 0))
+				sub.Add(sanity.NonZero("c", 0))
+				g := sanity.NewGuard(sanity.WithMaxErrors(2))
+				g.Merge(sub.Err())
+				return g.Stats()
+			},
+			expected: sanity.MGStats{Failures: 3, Kept: 2, Dropped: 1},
+		},
+		{
+			name: "Errors dropped by the source stay accounted for",
+			function: func() interface{} {
+				sub := sanity.NewGuard(sanity.WithMaxErrors(2))
+				sub.Add(sanity.NonZero("a", 0))
+				sub.Add(sanity.NonZero("b", 0))
+				sub.Add(sanity.NonZero("c", 0))
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Merge(sub.Err())
+				var ce sanity.ErrorsClampedError
+				return []interface{}{g.Stats(), errors.As(g.Err(), &ce) && ce.Dropped == 1}
+			},
+			expected: []interface{}{sanity.MGStats{Failures: 3, Kept: 2, Dropped: 1}, true},
+		},
+		{
+			name: "A single error is added as is",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.Merge(sanity.NonZero("n", 0))
+				return fieldOf(g.Err())
+			},
+			expected: "n",
+		},
+		{
+			name: "errors.Join results are folded in like aggregates",
+			function: func() interface{} {
+				sub := sanity.NewGuard(sanity.WithMaxErrors(0))
+				sub.Add(sanity.NonZero("a", 0))
+				sub.Add(sanity.NonZero("b", 0))
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Scope("x").Merge(errors.Join(sanity.NonZero("j", 0), sub.Err()))
+				return []interface{}{fieldsOf(g.Err()), g.Stats().Kept}
+			},
+			expected: []interface{}{[]string{"x.j", "x.a", "x.b"}, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestGuardFlatten(t *testing.T) {
+	sub := func(fields ...string) error {
+		g := sanity.NewGuard(sanity.WithMaxErrors(0))
+		for _, f := range fields {
+			g.Add(sanity.NonZero(f, 0))
+		}
+		return g.Err()
+	}
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Added aggregates are recorded member by member",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithFlatten())
+				g.Add(sanity.NonEmpty("name", ""))
+				g.Scope("db").Add(sub("port", "host"))
+				var n int
+				g.Err().(sanity.ErrorGroup).Iter(func(error) bool { n++; return true })
+				return []interface{}{fieldsOf(g.Err()), g.Stats().Kept, n}
+			},
+			expected: []interface{}{[]string{"name", "db.port", "db.host"}, 3, 3},
+		},
+		{
+			name: "Without the option the aggregate is one member",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("name", ""))
+				g.Add(sub("port", "host"))
+				return g.Stats().Kept
+			},
+			expected: 2,
+		},
+		{
+			name: "Cap applies to flattened members",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(2), sanity.WithFlatten())
+				kept := g.AddKeep(errors.Join(sanity.NonZero("a", 0), sub("b", "c")))
+				return []interface{}{g.Stats(), kept}
+			},
+			expected: []interface{}{sanity.MGStats{Failures: 3, Kept: 2, Dropped: 1}, false},
+		},
+		{
+			name: "Wrapped aggregates keep their context",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithFlatten())
+				g.Add(fmt.Errorf("load: %w", sub("a", "b")))
+				return g.Stats().Kept
+			},
+			expected: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestGuardOnError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Hook sees every failure, kept or dropped",
+			function: func() interface{} {
+				var seen []string
+				g := sanity.NewGuard(sanity.WithOnError(func(err error) { seen = append(seen, fieldOf(err)) }))
+				g.Check(nil)
+				g.Check(sanity.NonEmpty("a", ""))
+				g.Scope("db").Check(sanity.NonZero("port", 0)) // dropped
+				return seen
+			},
+			expected: []string{"a", "db.port"},
+		},
+		{
+			name: "Hook may use the Guard",
+			function: func() interface{} {
+				var g sanity.Guard
+				var kept []int
+				g = sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithThreadSafe(),
+					sanity.WithOnError(func(error) { kept = append(kept, g.Stats().Kept) }))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonEmpty("b", ""))
+				return kept
+			},
+			expected: []int{1, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestGuardStatsByCategory(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Counts failures per code, kept or dropped",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(2))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonEmpty("b", ""))
+				g.Add(sanity.InRangeNum("c", 0, 1, 2)) // dropped
+				g.Add(errors.New("foreign"))           // dropped
+				return g.StatsByCategory()
+			},
+			expected: map[string]int{"NON_EMPTY": 2, "OUT_OF_RANGE": 1, sanity.CategoryOther: 1},
+		},
+		{
+			name: "Many categories, scopes and Reset",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				s := g.Scope("x")
+				s.Add(sanity.NonEmpty("a", ""))
+				s.Add(sanity.NonZero("b", 0))
+				s.Add(sanity.NotNilPtr[int]("c", nil))
+				s.Add(sanity.ValidIP("d", ""))
+				s.Add(sanity.ValidPort("e", ""))
+				s.Add(sanity.ValidPort("f", ""))
+				before := g.StatsByCategory()
+				g.Reset()
+				return []interface{}{before, g.StatsByCategory()}
+			},
+			expected: []interface{}{
+				map[string]int{"NON_EMPTY": 1, "NON_ZERO": 1, "NOT_NIL": 1, "INVALID_IP": 1, "INVALID_PORT": 2},
+				map[string]int{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestGuardMaxPerCategory(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "At most n kept per category, the rest dropped",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithMaxPerCategory(2))
+				for _, f := range []string{"a", "b", "c", "d"} {
+					g.Add(sanity.NonEmpty(f, ""))
+				}
+				g.Add(sanity.NonZero("e", 0))
+				g.Add(errors.New("x"))
+				return []interface{}{fieldsOf(g.Err()), g.Stats(), g.StatsByCategory()["NON_EMPTY"]}
+			},
+			expected: []interface{}{
+				[]string{"a", "b", "e", "", ""}, // the last "" is the clamp sentinel
+				sanity.MGStats{Failures: 6, Kept: 4, Dropped: 2},
+				4,
+			},
+		},
+		{
+			name: "Global cap still applies",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(2), sanity.WithMaxPerCategory(5))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonZero("b", 0))
+				g.Add(sanity.NotNilPtr[int]("c", nil))
+				return g.Stats()
+			},
+			expected: sanity.MGStats{Failures: 3, Kept: 2, Dropped: 1},
+		},
+		{
+			name: "Reset clears per-category counts; dedup unaffected",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithMaxPerCategory(1), sanity.WithDedup())
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.NonEmpty("b", ""))
+				g.Add(sanity.NonEmpty("a", ""))
+				before := sanity.FormatList(g.Err(), "; ")
+				g.Reset()
+				g.Add(sanity.NonEmpty("b", ""))
+				return []string{before, sanity.FormatList(g.Err(), "; ")}
+			},
+			expected: []string{
+				"a: must be non-empty (x2); validation: 1 additional errors omitted (kept 1)",
+				"b: must be non-empty",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package sanity
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// WithTiming makes the Guard record how long each check evaluated through
+// AddCheck, CheckLazy, Run, RunNamed, RunCtx or RunParallel took; see Timings
+// and SlowestChecks. Without it no clock is read.
+func WithTiming() GuardOption {
+	return func(g *Guard) { g.timing = true }
+}
+
+// CheckTiming is the duration of one evaluated check.
+type CheckTiming struct {
+	// Check is the label of the Named view that ran the check, else the field
+	// of its error, else "".
+	Check    string
+	Duration time.Duration
+	Failed   bool
+}
+
+// Timings returns the recorded check durations in completion order, or nil
+// unless the Guard is WithTiming.
+func (gd *Guard) Timings() []CheckTiming {
+	r := gd.root()
+	r.lock()
+	defer r.unlock()
+	return slices.Clone(r.timings)
+}
+
+// SlowestChecks returns up to n recorded timings, slowest first.
+func (gd *Guard) SlowestChecks(n int) []CheckTiming {
+	out := gd.Timings()
+	slices.SortStableFunc(out, func(a, b CheckTiming) int { return cmp.Compare(b.Duration, a.Duration) })
+	return out[:min(max(n, 0), len(out))]
+}
+
+// checkStart returns the start time of a check, or the zero time unless the
+// Guard is WithTiming.
+func (gd *Guard) checkStart() time.Time {
+	if !gd.root().timing {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// recordTiming records a check started at start (see checkStart) that
+// returned err.
+func (gd *Guard) recordTiming(start time.Time, err error) {
+	if start.IsZero() {
+		return
+	}
+	gd.recordDuration(time.Since(start), err)
+}
+
+// recordDuration records a check that took d and returned err.
+func (gd *Guard) recordDuration(d time.Duration, err error) {
+	t := CheckTiming{Check: gd.checkLabel(), Duration: d, Failed: err != nil}
+	if t.Check == "" && err != nil {
+		t.Check = fieldPathOf(err)
+	}
+	r := gd.root()
+	r.lock()
+	r.timings = append(r.timings, t)
+	r.unlock()
+}
+
+// checkLabel returns the label of the innermost Named view, if any.
+func (gd *Guard) checkLabel() string {
+	for g := gd; g != nil; g = g.parent {
+		if g.label != "" {
+			return g.label
+		}
+	}
+	return ""
+}
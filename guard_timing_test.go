@@ -0,0 +1,96 @@
+package sanity_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func sleepCheck(d time.Duration, err error) sanity.Check {
+	return func() error {
+		time.Sleep(d)
+		return err
+	}
+}
+
+func TestGuardTiming(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Disabled by default",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.AddCheck(sleepCheck(0, nil))
+				return []interface{}{g.Timings() == nil, len(g.SlowestChecks(3))}
+			},
+			expected: []interface{}{true, 0},
+		},
+		{
+			name: "Names come from Named labels, else the failing field",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithTiming())
+				g.Named("dns").AddCheck(sleepCheck(0, errors.New("no such host")))
+				g.AddCheck(sleepCheck(0, sanity.NonZero("port", 0)))
+				g.CheckLazy(func() error { return nil })
+				g.RunCtx(context.Background(), func(context.Context) error { return nil })
+				var out []interface{}
+				for _, tm := range g.Timings() {
+					out = append(out, tm.Check, tm.Failed)
+				}
+				return out
+			},
+			expected: []interface{}{"dns", true, "port", true, "", false, "", false},
+		},
+		{
+			name: "SlowestChecks orders by duration",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithTiming())
+				g.RunParallel(context.Background(),
+					sleepCheck(time.Millisecond, sanity.NonZero("fast", 0)),
+					sleepCheck(30*time.Millisecond, sanity.NonZero("slow", 0)),
+					sleepCheck(0, nil),
+				)
+				slowest := g.SlowestChecks(1)
+				return []interface{}{len(g.Timings()), slowest[0].Check, slowest[0].Duration >= 30*time.Millisecond, len(g.SlowestChecks(10))}
+			},
+			expected: []interface{}{3, "slow", true, 3},
+		},
+		{
+			name: "Parallel timings exclude waiting to record the result",
+			function: func() interface{} {
+				slowHook := sanity.WithOnError(func(error) { time.Sleep(50 * time.Millisecond) })
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithTiming(), sanity.WithParallelism(2), slowHook)
+				g.RunParallel(context.Background(),
+					sleepCheck(0, sanity.NonZero("a", 0)),
+					sleepCheck(0, sanity.NonZero("b", 0)),
+				)
+				return g.SlowestChecks(1)[0].Duration < 40*time.Millisecond
+			},
+			expected: true,
+		},
+		{
+			name: "Reset clears timings",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithTiming())
+				g.AddCheck(sleepCheck(0, nil))
+				g.Reset()
+				return len(g.Timings())
+			},
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
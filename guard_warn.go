@@ -0,0 +1,37 @@
+package sanity
+
+// AddWarning records err (if non-nil) as a warning: a soft finding that is
+// reported by Warnings() and Stats but never by Err(), and never counts
+// toward the cap.
+func (gd *Guard) AddWarning(err error) {
+	if err == nil {
+		return
+	}
+	err = gd.decorate(err)
+	if gd.parent != nil {
+		gd.parent.AddWarning(err)
+		return
+	}
+	gd.lock()
+	gd.warnings = append(gd.warnings, err)
+	gd.unlock()
+}
+
+// Warnings returns the recorded warnings: nil if none, the warning itself if
+// there is one, otherwise an ErrorGroup.
+func (gd *Guard) Warnings() error {
+	gd = gd.root()
+	gd.lock()
+	defer gd.unlock()
+	switch len(gd.warnings) {
+	case 0:
+		return nil
+	case 1:
+		return gd.warnings[0]
+	}
+	m := &multiError{}
+	for _, w := range gd.warnings {
+		m.push(w)
+	}
+	return m
+}
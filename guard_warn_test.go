@@ -0,0 +1,64 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestGuardWarnings(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Warnings are separate from Err and the cap",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.AddWarning(errors.New("option 'legacy' is deprecated"))
+				g.AddWarning(nil)
+				g.Add(sanity.NonZero("port", 0))
+				g.AddWarning(sanity.InRangeNum("workers", 512, 1, 256))
+				return []interface{}{
+					len(sanity.GroupAsSlice(g.Err(), nil)),
+					len(sanity.GroupAsSlice(g.Warnings(), nil)),
+					errors.Is(g.Err(), sanity.ErrOutOfRange),
+					errors.Is(g.Warnings(), sanity.ErrOutOfRange),
+					g.Stats(),
+				}
+			},
+			expected: []interface{}{1, 2, false, true, sanity.MGStats{Failures: 1, Kept: 1, Warnings: 2}},
+		},
+		{
+			name: "Warnings only: Ok and nil Err",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.AddWarning(sanity.NonEmpty("motd", ""))
+				return []interface{}{g.Ok(), g.Err() == nil, g.Warnings().Error()}
+			},
+			expected: []interface{}{true, true, "motd: must be non-empty"},
+		},
+		{
+			name: "Scopes prefix warnings; Reset clears them",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.Scope("db").AddWarning(sanity.NonZero("pool", 0))
+				before := fieldOf(g.Warnings())
+				g.Reset()
+				return []interface{}{before, g.Warnings(), g.Stats().Warnings}
+			},
+			expected: []interface{}{"db.pool", nil, 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
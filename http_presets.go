@@ -0,0 +1,64 @@
+package sanity
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// SanitizeHTTPServer fills in zero (or negative) timeouts on s, which
+// net/http treats as "never time out", and caps extreme ones:
+//
+//	ReadTimeout        30s (max 10m)
+//	ReadHeaderTimeout  10s or ReadTimeout if shorter (max 1m)
+//	WriteTimeout       30s (max 10m)
+//	IdleTimeout        2m  (max 10m)
+//
+// Like SanitizeBackoff it returns nil if nothing changed, otherwise the
+// AdjustedErrors describing each correction. A nil s is a no-op.
+func SanitizeHTTPServer(s *http.Server) error {
+	if s == nil {
+		return nil
+	}
+	g := NewGuard(WithMaxErrors(0))
+	adjustTimeout(&g, "read_timeout", &s.ReadTimeout, 30*time.Second, 10*time.Minute)
+	adjustTimeout(&g, "read_header_timeout", &s.ReadHeaderTimeout, min(10*time.Second, s.ReadTimeout), time.Minute)
+	adjustTimeout(&g, "write_timeout", &s.WriteTimeout, 30*time.Second, 10*time.Minute)
+	adjustTimeout(&g, "idle_timeout", &s.IdleTimeout, 2*time.Minute, 10*time.Minute)
+	return g.Err()
+}
+
+// SanitizeHTTPTransport does the same for t, using http.DefaultTransport's
+// values as defaults:
+//
+//	dial timeout           30s (only when t has no Dial/DialContext)
+//	TLSHandshakeTimeout    10s (max 1m)
+//	IdleConnTimeout        90s (max 10m)
+//	ExpectContinueTimeout  1s  (max 10s)
+//
+// The dial adjustment is reported under "dial_timeout" with an Old value of 0.
+func SanitizeHTTPTransport(t *http.Transport) error {
+	if t == nil {
+		return nil
+	}
+	g := NewGuard(WithMaxErrors(0))
+	if t.DialContext == nil && t.Dial == nil {
+		d := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		t.DialContext = d.DialContext
+		g.Add(AdjustedError{Field: "dial_timeout", Old: time.Duration(0), New: d.Timeout})
+	}
+	adjustTimeout(&g, "tls_handshake_timeout", &t.TLSHandshakeTimeout, 10*time.Second, time.Minute)
+	adjustTimeout(&g, "idle_conn_timeout", &t.IdleConnTimeout, 90*time.Second, 10*time.Minute)
+	adjustTimeout(&g, "expect_continue_timeout", &t.ExpectContinueTimeout, time.Second, 10*time.Second)
+	return g.Err()
+}
+
+// adjustTimeout replaces a non-positive *p with def and caps it at max.
+func adjustTimeout(g *Guard, name string, p *time.Duration, def, max time.Duration) {
+	v := *p
+	if v <= 0 {
+		v = def
+	}
+	v = min(v, max)
+	adjust(g, name, p, v)
+}
@@ -0,0 +1,96 @@
+package sanity_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestSanitizeHTTP(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Zero server timeouts filled",
+			function: func() interface{} {
+				s := &http.Server{}
+				err := sanity.SanitizeHTTPServer(s)
+				return []interface{}{
+					[]time.Duration{s.ReadTimeout, s.ReadHeaderTimeout, s.WriteTimeout, s.IdleTimeout},
+					fieldsOf(err),
+					errors.Is(err, sanity.ErrAdjusted),
+				}
+			},
+			expected: []interface{}{
+				[]time.Duration{30 * time.Second, 10 * time.Second, 30 * time.Second, 2 * time.Minute},
+				[]string{"read_timeout", "read_header_timeout", "write_timeout", "idle_timeout"},
+				true,
+			},
+		},
+		{
+			name: "Server: extremes capped, short ReadTimeout bounds header default",
+			function: func() interface{} {
+				s := &http.Server{ReadTimeout: 2 * time.Second, WriteTimeout: 24 * time.Hour, IdleTimeout: time.Minute}
+				err := sanity.SanitizeHTTPServer(s)
+				return []interface{}{
+					[]time.Duration{s.ReadTimeout, s.ReadHeaderTimeout, s.WriteTimeout, s.IdleTimeout},
+					fieldsOf(err),
+				}
+			},
+			expected: []interface{}{
+				[]time.Duration{2 * time.Second, 2 * time.Second, 10 * time.Minute, time.Minute},
+				[]string{"read_header_timeout", "write_timeout"},
+			},
+		},
+		{
+			name: "Sane server and nil inputs untouched",
+			function: func() interface{} {
+				s := &http.Server{ReadTimeout: 5 * time.Second, ReadHeaderTimeout: time.Second, WriteTimeout: 5 * time.Second, IdleTimeout: time.Minute}
+				return []bool{
+					sanity.SanitizeHTTPServer(s) == nil,
+					sanity.SanitizeHTTPServer(nil) == nil,
+					sanity.SanitizeHTTPTransport(nil) == nil,
+				}
+			},
+			expected: []bool{true, true, true},
+		},
+		{
+			name: "Zero transport gets DefaultTransport-like timeouts and a dialer",
+			function: func() interface{} {
+				tr := &http.Transport{IdleConnTimeout: -1}
+				err := sanity.SanitizeHTTPTransport(tr)
+				return []interface{}{
+					tr.DialContext != nil,
+					[]time.Duration{tr.TLSHandshakeTimeout, tr.IdleConnTimeout, tr.ExpectContinueTimeout},
+					fieldsOf(err),
+				}
+			},
+			expected: []interface{}{
+				true,
+				[]time.Duration{10 * time.Second, 90 * time.Second, time.Second},
+				[]string{"dial_timeout", "tls_handshake_timeout", "idle_conn_timeout", "expect_continue_timeout"},
+			},
+		},
+		{
+			name: "Cloned DefaultTransport needs no changes",
+			function: func() interface{} {
+				tr := http.DefaultTransport.(*http.Transport).Clone()
+				return sanity.SanitizeHTTPTransport(tr) == nil
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Package httpsanity validates net/http requests with sanity and reports
+// failures as application/problem+json 400 responses.
+//
+//	func list(w http.ResponseWriter, r *http.Request) {
+//		g := httpsanity.Validate(r)
+//		limit := g.QueryInt("limit", 20, 1, 100)
+//		order := g.QueryInSet("order", "asc", "asc", "desc")
+//		if g.Respond(w) {
+//			return
+//		}
+//		...
+//	}
+package httpsanity
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sessaidi/sanity"
+)
+
+// Guard is a sanity.Guard bound to one request. It collects every failure
+// (no cap) unless options passed to Validate say otherwise.
+type Guard struct {
+	*sanity.Guard
+	r     *http.Request
+	query url.Values
+}
+
+// Validate returns a Guard for r; opts are applied after the default
+// sanity.WithMaxErrors(0).
+func Validate(r *http.Request, opts ...sanity.GuardOption) *Guard {
+	g := sanity.NewGuard(append([]sanity.GuardOption{sanity.WithMaxErrors(0)}, opts...)...)
+	return &Guard{Guard: &g, r: r, query: r.URL.Query()}
+}
+
+// queryValue returns the trimmed value of query parameter name, or "" if absent.
+func (g *Guard) queryValue(name string) string {
+	return strings.TrimSpace(g.query.Get(name))
+}
+
+// QueryInt parses query parameter name as an integer in [min,max]. An absent
+// or blank parameter yields def; an invalid or out-of-range one records a
+// FormatError or OutOfRangeError and yields def.
+func (g *Guard) QueryInt(name string, def, min, max int) int {
+	s := g.queryValue(name)
+	if s == "" {
+		return def
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		g.Add(sanity.FormatError{Field: name, Format: sanity.FormatInteger, Got: s})
+		return def
+	}
+	if err := sanity.InRangeNum(name, v, min, max); err != nil {
+		g.Add(err)
+		return def
+	}
+	return v
+}
+
+// QueryDurationClamp parses query parameter name with time.ParseDuration and
+// clamps it into [min,max]. An absent or blank parameter yields def; an
+// invalid one records a FormatError and yields def.
+func (g *Guard) QueryDurationClamp(name string, def, min, max time.Duration) time.Duration {
+	s := g.queryValue(name)
+	if s == "" {
+		return def
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		g.Add(sanity.FormatError{Field: name, Format: sanity.FormatDuration, Got: s})
+		return def
+	}
+	sanity.ClampDuration(&v, min, max)
+	return v
+}
+
+// QueryInSet returns query parameter name if it is one of allowed. An absent
+// or blank parameter yields def; any other value records a NotInSetError
+// listing allowed and yields def.
+func (g *Guard) QueryInSet(name, def string, allowed ...string) string {
+	s := g.queryValue(name)
+	if s == "" {
+		return def
+	}
+	if err := sanity.OneOf(name, s, allowed...); err != nil {
+		g.Add(err)
+		return def
+	}
+	return s
+}
+
+// Respond writes the Guard's errors as a problem+json 400, with the request
+// path as instance, and reports whether it did; it writes nothing if the
+// Guard is ok.
+func (g *Guard) Respond(w http.ResponseWriter) bool {
+	err := g.Err()
+	if err == nil {
+		return false
+	}
+	_ = sanity.WriteProblem(w, err,
+		sanity.WithProblemStatus(http.StatusBadRequest),
+		sanity.WithProblemInstance(g.r.URL.Path))
+	return true
+}
+
+// Middleware returns middleware that runs check against a fresh Guard for
+// each request and answers 400 instead of calling the next handler when the
+// Guard fails.
+//
+//	mux.Handle("/items", httpsanity.Middleware(func(g *httpsanity.Guard) {
+//		g.QueryInSet("order", "asc", "asc", "desc")
+//	})(items))
+func Middleware(check func(g *Guard), opts ...sanity.GuardOption) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			g := Validate(r, opts...)
+			check(g)
+			if g.Respond(w) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
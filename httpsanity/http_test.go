@@ -0,0 +1,110 @@
+package httpsanity_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+	"github.com/sessaidi/sanity/httpsanity"
+)
+
+func problemOf(rec *httptest.ResponseRecorder) sanity.Problem {
+	var p sanity.Problem
+	_ = json.Unmarshal(rec.Body.Bytes(), &p)
+	return p
+}
+
+func codesOf(p sanity.Problem) []string {
+	var out []string
+	for _, v := range p.Errors {
+		out = append(out, v.Field+"="+v.Code)
+	}
+	return out
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Valid and absent parameters",
+			function: func() interface{} {
+				r := httptest.NewRequest(http.MethodGet, "/items?limit=50&wait=1h&order=desc", nil)
+				g := httpsanity.Validate(r)
+				limit := g.QueryInt("limit", 20, 1, 100)
+				page := g.QueryInt("page", 1, 1, 1000)
+				wait := g.QueryDurationClamp("wait", time.Second, 0, time.Minute)
+				order := g.QueryInSet("order", "asc", "asc", "desc")
+				rec := httptest.NewRecorder()
+				return []interface{}{limit, page, wait, order, g.Respond(rec), rec.Code}
+			},
+			expected: []interface{}{50, 1, time.Minute, "desc", false, http.StatusOK},
+		},
+		{
+			name: "Every failure reported as problem+json 400",
+			function: func() interface{} {
+				r := httptest.NewRequest(http.MethodGet, "/items?limit=500&page=x&wait=soon&order=up", nil)
+				g := httpsanity.Validate(r)
+				limit := g.QueryInt("limit", 20, 1, 100)
+				g.QueryInt("page", 1, 1, 1000)
+				wait := g.QueryDurationClamp("wait", time.Second, 0, time.Minute)
+				order := g.QueryInSet("order", "asc", "asc", "desc")
+				rec := httptest.NewRecorder()
+				responded := g.Respond(rec)
+				p := problemOf(rec)
+				return []interface{}{
+					limit, wait, order, responded, rec.Code, rec.Header().Get("Content-Type"),
+					p.Status, p.Instance, codesOf(p), p.Errors[3].Allowed,
+				}
+			},
+			expected: []interface{}{
+				20, time.Second, "asc", true, http.StatusBadRequest, sanity.ProblemContentType,
+				http.StatusBadRequest, "/items",
+				[]string{"limit=OUT_OF_RANGE", "page=INVALID_FORMAT", "wait=INVALID_FORMAT", "order=NOT_IN_SET"},
+				[]string{"asc", "desc"},
+			},
+		},
+		{
+			name: "Guard options apply",
+			function: func() interface{} {
+				r := httptest.NewRequest(http.MethodGet, "/?a=x&b=y", nil)
+				g := httpsanity.Validate(r, sanity.WithMaxErrors(1), sanity.WithFieldPrefix("query"))
+				g.QueryInt("a", 0, 0, 1)
+				g.QueryInt("b", 0, 0, 1)
+				rec := httptest.NewRecorder()
+				g.Respond(rec)
+				return codesOf(problemOf(rec))
+			},
+			expected: []string{"query.a=INVALID_FORMAT", "=ERRORS_CLAMPED"},
+		},
+		{
+			name: "Middleware short-circuits on failure",
+			function: func() interface{} {
+				var calls int
+				h := httpsanity.Middleware(func(g *httpsanity.Guard) {
+					g.QueryInSet("order", "asc", "asc", "desc")
+				})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { calls++ }))
+				ok := httptest.NewRecorder()
+				h.ServeHTTP(ok, httptest.NewRequest(http.MethodGet, "/?order=desc", nil))
+				bad := httptest.NewRecorder()
+				h.ServeHTTP(bad, httptest.NewRequest(http.MethodGet, "/?order=random", nil))
+				return []int{calls, ok.Code, bad.Code}
+			},
+			expected: []int{1, http.StatusOK, http.StatusBadRequest},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Translator renders the message of err (without the field prefix) for
+// o.Locale. It returns ok=false to fall back to the default English message.
+// Implementations must honor o.Redact by not including offending values.
+type Translator interface {
+	Translate(err FieldError, o RenderOptions) (msg string, ok bool)
+}
+
+// MessageFunc adapts a plain function to Translator; return "" to fall back to
+// the default message. When redacting it is skipped for errors that carry
+// offending values, since it cannot know to omit them.
+type MessageFunc func(err FieldError, lang string) string
+
+func (f MessageFunc) Translate(err FieldError, o RenderOptions) (string, bool) {
+	if o.Redact && carriesValues(err) {
+		return "", false
+	}
+	msg := f(err, o.Locale)
+	return msg, msg != ""
+}
+
+var translator atomic.Pointer[Translator]
+
+// SetTranslator installs the process-wide translator used when rendering with
+// a Locale (see WithLocale, RenderCtx). nil removes it.
+func SetTranslator(t Translator) {
+	if t == nil {
+		translator.Store(nil)
+		return
+	}
+	translator.Store(&t)
+}
+
+// SetMessageFunc is shorthand for SetTranslator(MessageFunc(fn)).
+func SetMessageFunc(fn func(err FieldError, lang string) string) {
+	if fn == nil {
+		SetTranslator(nil)
+		return
+	}
+	SetTranslator(MessageFunc(fn))
+}
+
+// carriesValues reports whether redaction changes err's message.
+func carriesValues(err error) bool {
+	m, ok := err.(messager)
+	return !ok || m.message(true) != m.message(false)
+}
+
+// translate returns the localized message for err, if a translator is
+// installed and knows o.Locale.
+func translate(err error, o RenderOptions) (string, bool) {
+	if o.Locale == "" {
+		return "", false
+	}
+	t := translator.Load()
+	if t == nil {
+		return "", false
+	}
+	fe, ok := err.(FieldError)
+	if !ok {
+		return "", false
+	}
+	return (*t).Translate(fe, o)
+}
+
+// Catalog is a Translator backed by message templates, keyed by language and
+// then error code (see CodeOf). Templates may use {field}, {min}, {max},
+// {want}, {got} and {allowed}. When redacting, a "<CODE>:redacted" template is preferred;
+// templates that reference {got} are otherwise skipped.
+//
+// Lookups try the full tag ("fr-CA") and then the base language ("fr").
+type Catalog map[string]map[string]string
+
+func (c Catalog) Translate(err FieldError, o RenderOptions) (string, bool) {
+	msgs, ok := c[o.Locale]
+	if !ok {
+		base, _, cut := strings.Cut(o.Locale, "-")
+		if !cut {
+			return "", false
+		}
+		if msgs, ok = c[base]; !ok {
+			return "", false
+		}
+	}
+	code := CodeOf(err)
+	if code == "" {
+		return "", false
+	}
+	tmpl, ok := msgs[code]
+	if o.Redact {
+		if r, rok := msgs[code+":redacted"]; rok {
+			tmpl, ok = r, true
+		} else if strings.Contains(tmpl, "{got}") {
+			return "", false
+		}
+	}
+	if !ok {
+		return "", false
+	}
+	return expand(tmpl, err), true
+}
+
+func expand(tmpl string, err FieldError) string {
+	if !strings.Contains(tmpl, "{") {
+		return tmpl
+	}
+	args := []string{"{field}", err.FieldName()}
+	var re RangeError
+	if errors.As(err, &re) {
+		min, max := re.Bounds()
+		args = append(args, "{min}", fmt.Sprint(min), "{max}", fmt.Sprint(max), "{got}", fmt.Sprint(re.Value()))
+	}
+	var se SetError
+	if errors.As(err, &se) {
+		args = append(args, "{allowed}", strings.Join(se.AllowedValues(), ", "))
+	}
+	var lb lenBound
+	if errors.As(err, &lb) {
+		want, got := lb.lenWantGot()
+		args = append(args, "{want}", strconv.Itoa(want), "{got}", strconv.Itoa(got))
+	}
+	return strings.NewReplacer(args...).Replace(tmpl)
+}
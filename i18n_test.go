@@ -0,0 +1,114 @@
+package sanity_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+var testCatalog = sanity.Catalog{
+	"fr": {
+		sanity.CodeNonEmpty:                 "ne doit pas être vide",
+		sanity.CodeOutOfRange:               "doit être dans [{min},{max}], reçu {got}",
+		sanity.CodeOutOfRange + ":redacted": "doit être dans [{min},{max}]",
+	},
+	"de": {
+		sanity.CodeNonEmpty:   "darf nicht leer sein",
+		sanity.CodeLenAtLeast: "Länge muss >= {want} sein (ist {got})",
+		sanity.CodeNotInSet:   "muss einer von {allowed} sein",
+	},
+}
+
+func TestI18n(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Catalog renders per locale, falls back to English",
+			function: func() interface{} {
+				sanity.SetTranslator(testCatalog)
+				defer sanity.SetTranslator(nil)
+				err := sanity.NonEmpty("name", "")
+				return []string{
+					sanity.RenderCtx(sanity.WithLocale(context.Background(), "fr"), err).Error(),
+					sanity.RenderCtx(sanity.WithLocale(context.Background(), "de-AT"), err).Error(),
+					sanity.RenderCtx(sanity.WithLocale(context.Background(), "es"), err).Error(),
+					err.Error(),
+				}
+			},
+			expected: []string{"name: ne doit pas être vide", "name: darf nicht leer sein", "name: must be non-empty", "name: must be non-empty"},
+		},
+		{
+			name: "Placeholders and redacted templates",
+			function: func() interface{} {
+				sanity.SetTranslator(testCatalog)
+				defer sanity.SetTranslator(nil)
+				fr := sanity.WithLocale(context.Background(), "fr")
+				de := sanity.WithLocale(context.Background(), "de")
+				verbose := sanity.RenderCtx(de, sanity.StrLenAtLeast("pw", "ab", 8)).Error()
+				return []interface{}{
+					sanity.RenderCtx(sanity.WithOptions(fr, sanity.Redact()), sanity.InRangeNum("port", 0, 1, 10)).Error(),
+					verbose == "pw: Länge muss >= 8 sein (ist 2)" || sanity.RedactBuild,
+					sanity.RenderCtx(sanity.WithOptions(de, sanity.Redact()), sanity.StrLenAtLeast("pw", "ab", 8)).Error(),
+				}
+			},
+			expected: []interface{}{"port: doit être dans [1,10]", true, "pw: len must be >= 8"},
+		},
+		{
+			name: "Allowed placeholder",
+			function: func() interface{} {
+				sanity.SetTranslator(testCatalog)
+				defer sanity.SetTranslator(nil)
+				de := sanity.WithLocale(context.Background(), "de")
+				return sanity.RenderCtx(de, sanity.OneOf("mode", "x", "auto", "manual")).Error()
+			},
+			expected: "mode: muss einer von auto, manual sein",
+		},
+		{
+			name: "ErrCtx localizes every member and keeps prefixes",
+			function: func() interface{} {
+				sanity.SetTranslator(testCatalog)
+				defer sanity.SetTranslator(nil)
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithRedaction())
+				g.Scope("srv").Add(sanity.NonEmpty("host", ""))
+				g.Add(sanity.NonZero("n", 0))
+				var msgs []string
+				for _, e := range sanity.GroupAsSlice(g.ErrCtx(sanity.WithLocale(context.Background(), "fr")), nil) {
+					msgs = append(msgs, e.Error())
+				}
+				return msgs
+			},
+			expected: []string{"srv.host: ne doit pas être vide", "n: must be non-zero"},
+		},
+		{
+			name: "SetMessageFunc",
+			function: func() interface{} {
+				sanity.SetMessageFunc(func(err sanity.FieldError, lang string) string {
+					if lang == "de" && sanity.CodeOf(err) == sanity.CodeNotNil {
+						return "darf nicht nil sein"
+					}
+					return ""
+				})
+				defer sanity.SetMessageFunc(nil)
+				ctx := sanity.WithLocale(context.Background(), "de")
+				return []string{
+					sanity.RenderCtx(ctx, sanity.NotNilPtr[int]("p", nil)).Error(),
+					sanity.RenderCtx(ctx, sanity.NonZero("n", 0)).Error(),
+				}
+			},
+			expected: []string{"p: darf nicht nil sein", "n: must be non-zero"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package sanity
+
+import "unique"
+
+// Intern returns a canonical copy of s. Interning dynamically built field
+// names (e.g. from a schema or a decoder) before passing them to validators
+// lets millions of error values share one backing string instead of each
+// holding its own copy. Canonical strings are reclaimed by the GC once unused.
+func Intern(s string) string {
+	return unique.Make(s).Value()
+}
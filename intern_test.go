@@ -0,0 +1,51 @@
+package sanity_test
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestIntern(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Intern preserves value",
+			function: func() interface{} {
+				return sanity.Intern("port")
+			},
+			expected: "port",
+		},
+		{
+			name: "Interned copies share backing storage",
+			function: func() interface{} {
+				a := sanity.Intern(strings.Repeat("x", 3) + ".port")
+				b := sanity.Intern(strings.Repeat("x", 3) + ".port")
+				return unsafe.StringData(a) == unsafe.StringData(b)
+			},
+			expected: true,
+		},
+		{
+			name: "Interned name flows into typed errors",
+			function: func() interface{} {
+				name := sanity.Intern(strings.ToLower("PORT"))
+				return sanity.NonZero(name, 0).Error()
+			},
+			expected: "port: must be non-zero",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
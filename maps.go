@@ -0,0 +1,37 @@
+package sanity
+
+// InitMapIfNil sets *p to an empty map with room for capHint entries if it is
+// nil, so callers can assign to it unconditionally; SetIfNil cannot express
+// this since a map is not a **T. A negative capHint is treated as 0.
+func InitMapIfNil[K comparable, V any](p *map[K]V, capHint int) {
+	if *p == nil {
+		*p = make(map[K]V, max(capHint, 0))
+	}
+}
+
+// MapDropZeroValues deletes the entries of m whose value is the zero value,
+// so settings left empty ("" or 0) fall back to defaults like absent keys.
+func MapDropZeroValues[K comparable, V comparable](m map[K]V) {
+	var zero V
+	for k, v := range m {
+		if v == zero {
+			delete(m, k)
+		}
+	}
+}
+
+// MapSetIfMissing sets m[key] to def if key is absent; a present key keeps its
+// value, even a zero one. m must not be nil.
+func MapSetIfMissing[K comparable, V any](m map[K]V, key K, def V) {
+	if _, ok := m[key]; !ok {
+		m[key] = def
+	}
+}
+
+// MapGetOr returns m[key], or def if key is absent (m may be nil).
+func MapGetOr[K comparable, V any](m map[K]V, key K, def V) V {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,67 @@
+package sanity_test
+
+import (
+	"testing"
+
+	"github.com/sessaidi/sanity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapSanitizers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "InitMapIfNil",
+			function: func() interface{} {
+				var m map[string]int
+				sanity.InitMapIfNil(&m, 4)
+				m["a"] = 1
+				keep := m
+				sanity.InitMapIfNil(&m, -1)
+				m["b"] = 2
+				var neg map[string]int
+				sanity.InitMapIfNil(&neg, -1)
+				return []interface{}{m, len(keep), neg != nil}
+			},
+			expected: []interface{}{map[string]int{"a": 1, "b": 2}, 2, true},
+		},
+		{
+			name: "MapDropZeroValues",
+			function: func() interface{} {
+				m := map[string]string{"a": "", "b": "x", "c": ""}
+				sanity.MapDropZeroValues(m)
+				sanity.MapDropZeroValues[string, int](nil)
+				return m
+			},
+			expected: map[string]string{"b": "x"},
+		},
+		{
+			name: "MapSetIfMissing keeps present keys, even zero ones",
+			function: func() interface{} {
+				m := map[string]int{"a": 0, "b": 2}
+				sanity.MapSetIfMissing(m, "a", 10)
+				sanity.MapSetIfMissing(m, "c", 30)
+				return m
+			},
+			expected: map[string]int{"a": 0, "b": 2, "c": 30},
+		},
+		{
+			name: "MapGetOr",
+			function: func() interface{} {
+				m := map[string]int{"a": 0}
+				var nilM map[string]int
+				return []int{sanity.MapGetOr(m, "a", 5), sanity.MapGetOr(m, "b", 5), sanity.MapGetOr(nilM, "a", 7)}
+			},
+			expected: []int{0, 5, 7},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
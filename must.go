@@ -0,0 +1,36 @@
+package sanity
+
+import "fmt"
+
+// Must returns v, or panics with err (wrapped, so errors.Is still works on the
+// recovered value). Use it in wiring code where failure is unrecoverable.
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(fmt.Errorf("sanity: Must: %w", err))
+	}
+	return v
+}
+
+// MustOk returns v, or panics if ok is false (comma-ok lookups, type assertions).
+func MustOk[T any](v T, ok bool) T {
+	if !ok {
+		panic(fmt.Errorf("sanity: MustOk: no %T value", v))
+	}
+	return v
+}
+
+// Must panics with the Guard's error (wrapped, so errors.Is/As still reach the
+// aggregate and its members) if any check failed.
+func (gd *Guard) Must() {
+	if err := gd.Err(); err != nil {
+		panic(fmt.Errorf("sanity: Guard.Must: %w", err))
+	}
+}
+
+// MustValidate runs every check with an unlimited Guard and panics with the
+// aggregate if any failed. Intended for init-time configuration.
+func MustValidate(checks ...Check) {
+	g := NewGuard(WithMaxErrors(0))
+	g.Run(checks...)
+	g.Must()
+}
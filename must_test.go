@@ -0,0 +1,114 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func recovered(fn func()) (r any) {
+	defer func() { r = recover() }()
+	fn()
+	return nil
+}
+
+func TestMust(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Must nil error returns value",
+			function: func() interface{} {
+				return sanity.Must(42, nil)
+			},
+			expected: 42,
+		},
+		{
+			name: "Must panics with wrapped error",
+			function: func() interface{} {
+				r := recovered(func() { sanity.Must(0, sanity.NonZero("port", 0)) })
+				err, ok := r.(error)
+				return ok && errors.Is(err, sanity.ErrNonZero)
+			},
+			expected: true,
+		},
+		{
+			name: "Must panic message carries context",
+			function: func() interface{} {
+				r := recovered(func() { sanity.Must(0, sanity.NonZero("port", 0)) })
+				return r.(error).Error()
+			},
+			expected: "sanity: Must: port: must be non-zero",
+		},
+		{
+			name: "MustOk true returns value",
+			function: func() interface{} {
+				m := map[string]int{"a": 1}
+				v, ok := m["a"]
+				return sanity.MustOk(v, ok)
+			},
+			expected: 1,
+		},
+		{
+			name: "MustOk false panics naming the type",
+			function: func() interface{} {
+				r := recovered(func() { sanity.MustOk("", false) })
+				return r.(error).Error()
+			},
+			expected: "sanity: MustOk: no string value",
+		},
+		{
+			name: "Guard.Must without failures does not panic",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.Check(sanity.NonZero("port", 8080))
+				return recovered(g.Must)
+			},
+			expected: nil,
+		},
+		{
+			name: "Guard.Must panics with the aggregate",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonZero("port", 0))
+				g.Add(sanity.NonEmpty("host", ""))
+				err := recovered(g.Must).(error)
+				return []interface{}{len(sanity.GroupAsSlice(err, nil)), errors.Is(err, sanity.ErrNonEmpty)}
+			},
+			expected: []interface{}{2, true},
+		},
+		{
+			name: "MustValidate runs every check",
+			function: func() interface{} {
+				err := recovered(func() {
+					sanity.MustValidate(
+						func() error { return sanity.NonZero("port", 0) },
+						func() error { return nil },
+						func() error { return sanity.NonEmpty("host", "") },
+					)
+				}).(error)
+				return len(sanity.GroupAsSlice(err, nil))
+			},
+			expected: 2,
+		},
+		{
+			name: "MustValidate passes",
+			function: func() interface{} {
+				return recovered(func() { sanity.MustValidate(func() error { return nil }) })
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
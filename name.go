@@ -0,0 +1,59 @@
+package sanity
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Name is a field name resolved only when a validator fails. Build it with
+// N, NameFunc, NameOf or Indexed; the zero Name resolves to "".
+type Name struct {
+	s     string
+	fn    func() string
+	st    fmt.Stringer
+	index int
+	kind  uint8
+}
+
+const (
+	nameStatic uint8 = iota
+	nameFunc
+	nameStringer
+	nameIndexed
+)
+
+// N wraps a static field name.
+func N(s string) Name { return Name{s: s} }
+
+// NameFunc defers the field name to fn.
+func NameFunc(fn func() string) Name { return Name{fn: fn, kind: nameFunc} }
+
+// NameOf defers the field name to s.String().
+func NameOf(s fmt.Stringer) Name { return Name{st: s, kind: nameStringer} }
+
+// Indexed names element i of base, rendered as "base[i]".
+func Indexed(base string, i int) Name { return Name{s: base, index: i, kind: nameIndexed} }
+
+// String resolves the name.
+func (n Name) String() string {
+	switch n.kind {
+	case nameFunc:
+		if n.fn != nil {
+			return n.fn()
+		}
+	case nameStringer:
+		if n.st != nil {
+			return n.st.String()
+		}
+	case nameIndexed:
+		b := make([]byte, 0, len(n.s)+8)
+		b = append(b, n.s...)
+		b = append(b, '[')
+		b = strconv.AppendInt(b, int64(n.index), 10)
+		b = append(b, ']')
+		return string(b)
+	default:
+		return n.s
+	}
+	return ""
+}
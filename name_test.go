@@ -0,0 +1,102 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+type pathStringer struct{ parts []string }
+
+func (p pathStringer) String() string {
+	out := ""
+	for i, s := range p.parts {
+		if i > 0 {
+			out += "."
+		}
+		out += s
+	}
+	return out
+}
+
+func TestNameValidators(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Name kinds resolve",
+			function: func() interface{} {
+				return []string{
+					sanity.N("port").String(),
+					sanity.NameFunc(func() string { return "lazy" }).String(),
+					sanity.NameOf(pathStringer{parts: []string{"server", "tls"}}).String(),
+					sanity.Indexed("items", 12).String(),
+					sanity.Name{}.String(),
+				}
+			},
+			expected: []string{"port", "lazy", "server.tls", "items[12]", ""},
+		},
+		{
+			name: "NameFunc not evaluated on success",
+			function: func() interface{} {
+				calls := 0
+				n := sanity.NameFunc(func() string { calls++; return "x" })
+				_ = sanity.NonEmptyN(n, "ok")
+				_ = sanity.InRangeNumN(n, 5, 1, 10)
+				_ = sanity.NonEmptyN(n, "")
+				return calls
+			},
+			expected: 1,
+		},
+		{
+			name: "Failures carry resolved names",
+			function: func() interface{} {
+				set := map[string]struct{}{"a": {}}
+				return []string{
+					fieldOf(sanity.NotNilPtrN[int](sanity.Indexed("p", 0), nil)),
+					fieldOf(sanity.NonZeroN(sanity.Indexed("n", 1), 0)),
+					fieldOf(sanity.NonBlankN(sanity.Indexed("s", 2), " ")),
+					fieldOf(sanity.StrLenAtLeastN(sanity.Indexed("s", 3), "", 1)),
+					fieldOf(sanity.SliceLenAtLeastN(sanity.Indexed("xs", 4), []int{}, 1)),
+					fieldOf(sanity.MapLenAtLeastN(sanity.Indexed("m", 5), map[int]int{}, 1)),
+					fieldOf(sanity.InSetN(sanity.Indexed("mode", 6), "z", set)),
+					fieldOf(sanity.InRangeDurationN(sanity.Indexed("d", 7), 0, time.Second, time.Minute)),
+					fieldOf(sanity.StrLenAtMostN(sanity.Indexed("s", 8), "ab", 1)),
+					fieldOf(sanity.SliceLenAtMostN(sanity.Indexed("xs", 9), []int{1}, 0)),
+					fieldOf(sanity.MapLenAtMostN(sanity.Indexed("m", 10), map[int]int{1: 1}, 0)),
+					fieldOf(sanity.StrLenBetweenN(sanity.Indexed("s", 11), "", 1, 2)),
+					fieldOf(sanity.SliceLenBetweenN(sanity.Indexed("xs", 12), []int{}, 1, 2)),
+					fieldOf(sanity.MapLenBetweenN(sanity.Indexed("m", 13), map[int]int{}, 1, 2)),
+				}
+			},
+			expected: []string{
+				"p[0]", "n[1]", "s[2]", "s[3]", "xs[4]", "m[5]", "mode[6]", "d[7]",
+				"s[8]", "xs[9]", "m[10]", "s[11]", "xs[12]", "m[13]",
+			},
+		},
+		{
+			name: "Range error keeps bounds",
+			function: func() interface{} {
+				err := sanity.InRangeNumN(sanity.N("port"), 0, 65535, 1)
+				var re sanity.RangeError
+				ok := errors.As(err, &re)
+				min, max := re.Bounds()
+				return []interface{}{ok, min, max}
+			},
+			expected: []interface{}{true, 1, 65535},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
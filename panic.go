@@ -0,0 +1,42 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrPanic is the category sentinel for recovered panics.
+var ErrPanic = errors.New("sanity:panic")
+
+// PanicError carries a recovered panic value and the stack at recovery time.
+// It matches ErrPanic via errors.Is and unwraps to Value when Value is an error.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e PanicError) Error() string { return fmt.Sprintf("panic: %v", e.Value) }
+
+func (e PanicError) Is(target error) bool { return target == ErrPanic }
+
+func (e PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// CatchPanic runs fn and converts a panic into a PanicError.
+func CatchPanic(fn func() error) (err error) {
+	defer RecoverInto(&err)
+	return fn()
+}
+
+// RecoverInto stores a recovered panic as a PanicError in *errp. It must be
+// deferred directly: defer sanity.RecoverInto(&err).
+func RecoverInto(errp *error) {
+	if r := recover(); r != nil {
+		*errp = PanicError{Value: r, Stack: debug.Stack()}
+	}
+}
@@ -0,0 +1,76 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestPanicConversion(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "CatchPanic passes through returned error",
+			function: func() interface{} {
+				err := sanity.CatchPanic(func() error { return sanity.NonEmpty("s", "") })
+				return []bool{errors.Is(err, sanity.ErrNonEmpty), errors.Is(err, sanity.ErrPanic)}
+			},
+			expected: []bool{true, false},
+		},
+		{
+			name: "CatchPanic converts panic value",
+			function: func() interface{} {
+				err := sanity.CatchPanic(func() error { panic("boom") })
+				var pe sanity.PanicError
+				ok := errors.As(err, &pe)
+				return []interface{}{errors.Is(err, sanity.ErrPanic), ok, pe.Value, len(pe.Stack) > 0, err.Error()}
+			},
+			expected: []interface{}{true, true, "boom", true, "panic: boom"},
+		},
+		{
+			name: "Panic with error value unwraps to it",
+			function: func() interface{} {
+				err := sanity.CatchPanic(func() error { panic(sanity.NonZero("n", 0)) })
+				return errors.Is(err, sanity.ErrNonZero)
+			},
+			expected: true,
+		},
+		{
+			name: "RecoverInto via defer",
+			function: func() interface{} {
+				f := func() (err error) {
+					defer sanity.RecoverInto(&err)
+					var m map[string]int
+					m["x"] = 1
+					return nil
+				}
+				return errors.Is(f(), sanity.ErrPanic)
+			},
+			expected: true,
+		},
+		{
+			name: "Guard aggregate keeps PanicError discoverable",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("a", ""))
+				g.Add(sanity.CatchPanic(func() error { panic(42) }))
+				var pe sanity.PanicError
+				return errors.As(g.Err(), &pe) && pe.Value == 42
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
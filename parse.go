@@ -0,0 +1,45 @@
+package sanity
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseIntOr parses s as a base-10 integer, ignoring surrounding whitespace,
+// and returns def if s is blank, invalid or out of int range.
+func ParseIntOr(s string, def int) int {
+	v, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// ParseIntClamp is ParseIntOr followed by Clamp into [min,max].
+func ParseIntClamp(s string, def, min, max int) int {
+	v := ParseIntOr(s, def)
+	Clamp(&v, min, max)
+	return v
+}
+
+// ParseFloatOr parses s with strconv.ParseFloat, ignoring surrounding
+// whitespace, and returns def if s is blank, invalid or not finite ("NaN",
+// "Inf", or beyond float64 range).
+func ParseFloatOr(s string, def float64) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return def
+	}
+	return v
+}
+
+// ParseBoolOr parses s with strconv.ParseBool ("1", "true", "F", ...),
+// ignoring surrounding whitespace, and returns def if s is blank or invalid.
+func ParseBoolOr(s string, def bool) bool {
+	v, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,76 @@
+package sanity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestParseOr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "ParseIntOr parses and falls back",
+			function: func() interface{} {
+				return []int{
+					sanity.ParseIntOr(" 42 ", 7),
+					sanity.ParseIntOr("-3", 7),
+					sanity.ParseIntOr("", 7),
+					sanity.ParseIntOr("4.2", 7),
+					sanity.ParseIntOr("99999999999999999999", 7),
+				}
+			},
+			expected: []int{42, -3, 7, 7, 7},
+		},
+		{
+			name: "ParseIntClamp clamps parsed values and defaults",
+			function: func() interface{} {
+				return []int{
+					sanity.ParseIntClamp("500", 10, 1, 100),
+					sanity.ParseIntClamp("0", 10, 1, 100),
+					sanity.ParseIntClamp("x", 10, 1, 100),
+					sanity.ParseIntClamp("x", 1000, 1, 100),
+				}
+			},
+			expected: []int{100, 1, 10, 100},
+		},
+		{
+			name: "ParseFloatOr rejects non-finite values",
+			function: func() interface{} {
+				return []float64{
+					sanity.ParseFloatOr(" 0.25 ", 1),
+					sanity.ParseFloatOr("1e3", 1),
+					sanity.ParseFloatOr("NaN", 1),
+					sanity.ParseFloatOr("-Inf", 1),
+					sanity.ParseFloatOr("1e999", 1),
+					sanity.ParseFloatOr("", 1),
+				}
+			},
+			expected: []float64{0.25, 1000, 1, 1, 1, 1},
+		},
+		{
+			name: "ParseBoolOr parses and falls back",
+			function: func() interface{} {
+				return []bool{
+					sanity.ParseBoolOr(" true ", false),
+					sanity.ParseBoolOr("0", true),
+					sanity.ParseBoolOr("yes", true),
+					sanity.ParseBoolOr("", false),
+				}
+			},
+			expected: []bool{true, false, true, false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
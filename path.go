@@ -0,0 +1,76 @@
+package sanity
+
+import "strings"
+
+// FieldPathError attaches a field path to an error that cannot carry one itself
+// (third-party errors, contract/panic errors, clamp sentinels).
+type FieldPathError struct {
+	Path string
+	Err  error
+}
+
+func (e FieldPathError) FieldName() string { return e.Path }
+func (e FieldPathError) Unwrap() error     { return e.Err }
+
+func (e FieldPathError) Error() string { return e.Path + ": " + e.message(redacting()) }
+
+func (e FieldPathError) message(redact bool) string {
+	if m, ok := e.Err.(messager); ok {
+		return m.message(redact)
+	}
+	msg := e.Err.Error()
+	if fe, ok := e.Err.(FieldError); ok {
+		msg = strings.TrimPrefix(msg, fe.FieldName()+": ")
+	}
+	return msg
+}
+
+// JoinPath joins a prefix and a field name: "a"+"b" => "a.b", "a"+"[0]" => "a[0]".
+func JoinPath(prefix, field string) string {
+	switch {
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	case field[0] == '[':
+		return prefix + field
+	default:
+		return prefix + "." + field
+	}
+}
+
+// WithPrefix returns err with its field path prefixed by prefix. Typed errors of
+// this package keep their concrete type; aggregates have every member prefixed;
+// anything else is wrapped in a FieldPathError.
+func WithPrefix(prefix string, err error) error {
+	if err == nil || prefix == "" {
+		return err
+	}
+	switch e := err.(type) {
+	case fieldRenamer:
+		return e.withFieldName(JoinPath(prefix, e.FieldName()))
+	case FieldPathError:
+		e.Path = JoinPath(prefix, e.Path)
+		return e
+	case renderedError:
+		e.err = WithPrefix(prefix, e.err)
+		return e
+	case RepeatedError:
+		e.Err = WithPrefix(prefix, e.Err)
+		return e
+	case CallerError:
+		e.Err = WithPrefix(prefix, e.Err)
+		return e
+	case ErrorGroup:
+		out := groupLike(err)
+		e.Iter(func(m error) bool {
+			out.push(WithPrefix(prefix, m))
+			return true
+		})
+		return out
+	case FieldError:
+		return FieldPathError{Path: JoinPath(prefix, e.FieldName()), Err: err}
+	default:
+		return FieldPathError{Path: prefix, Err: err}
+	}
+}
@@ -0,0 +1,88 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestPaths(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "JoinPath forms",
+			function: func() interface{} {
+				return []string{
+					sanity.JoinPath("", "port"),
+					sanity.JoinPath("server", ""),
+					sanity.JoinPath("server", "port"),
+					sanity.JoinPath("items", "[2]"),
+				}
+			},
+			expected: []string{"port", "server", "server.port", "items[2]"},
+		},
+		{
+			name: "WithPrefix keeps concrete typed error",
+			function: func() interface{} {
+				err := sanity.WithPrefix("server.tls", sanity.InRangeNum("port", 0, 1, 10))
+				var oe sanity.OutOfRangeError[int]
+				ok := errors.As(err, &oe)
+				return []interface{}{ok, oe.Field, errors.Is(err, sanity.ErrOutOfRange)}
+			},
+			expected: []interface{}{true, "server.tls.port", true},
+		},
+		{
+			name: "WithPrefix wraps foreign errors",
+			function: func() interface{} {
+				base := errors.New("dial tcp: refused")
+				err := sanity.WithPrefix("db", base)
+				var pe sanity.FieldPathError
+				return []interface{}{errors.As(err, &pe), pe.Path, errors.Is(err, base), err.Error()}
+			},
+			expected: []interface{}{true, "db", true, "db: dial tcp: refused"},
+		},
+		{
+			name: "WithPrefix nests FieldPathError",
+			function: func() interface{} {
+				err := sanity.WithPrefix("a", sanity.WithPrefix("b", errors.New("x")))
+				return []string{fieldOf(err), err.Error()}
+			},
+			expected: []string{"a.b", "a.b: x"},
+		},
+		{
+			name: "WithPrefix prefixes aggregate members",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("host", ""))
+				g.Add(sanity.NonZero("port", 0))
+				var names []string
+				for _, e := range sanity.GroupAsSlice(sanity.WithPrefix("server", g.Err()), nil) {
+					names = append(names, fieldOf(e))
+				}
+				return names
+			},
+			expected: []string{"server.host", "server.port"},
+		},
+		{
+			name: "WithPrefix nil and empty prefix passthrough",
+			function: func() interface{} {
+				e := sanity.NonZero("n", 0)
+				return []bool{sanity.WithPrefix("x", nil) == nil, sanity.WithPrefix("", e) == e}
+			},
+			expected: []bool{true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
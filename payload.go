@@ -0,0 +1,54 @@
+package sanity
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// FieldViolation is a single failure entry of an ErrorPayload.
+type FieldViolation struct {
+	Field   string   `json:"field,omitempty"`
+	Code    string   `json:"code,omitempty"`
+	Message string   `json:"message"`
+	Allowed []string `json:"allowed,omitempty"` // acceptable values, see SetError
+}
+
+// ErrorPayload is the standardized response body for bind/validation failures.
+type ErrorPayload struct {
+	Status  int              `json:"status"`
+	Message string           `json:"message"`
+	Errors  []FieldViolation `json:"errors,omitempty"`
+}
+
+// NewErrorPayload builds a validation payload (422) from err, one entry per member.
+func NewErrorPayload(err error) ErrorPayload {
+	p := ErrorPayload{Status: http.StatusUnprocessableEntity, Message: "validation failed"}
+	for _, e := range GroupAsSlice(err, nil) {
+		p.Errors = append(p.Errors, violationOf(e))
+	}
+	return p
+}
+
+// NewBindErrorPayload builds a decode payload (400) for a request that could not be bound.
+func NewBindErrorPayload(err error) ErrorPayload {
+	p := ErrorPayload{Status: http.StatusBadRequest, Message: "invalid request body"}
+	if err != nil {
+		p.Errors = []FieldViolation{{Message: err.Error()}}
+	}
+	return p
+}
+
+func violationOf(err error) FieldViolation {
+	v := FieldViolation{Code: CodeOf(err), Message: err.Error()}
+	var fe FieldError
+	if errors.As(err, &fe) {
+		v.Field = fe.FieldName()
+		v.Message = strings.TrimPrefix(v.Message, v.Field+": ")
+	}
+	var se SetError
+	if errors.As(err, &se) {
+		v.Allowed = se.AllowedValues()
+	}
+	return v
+}
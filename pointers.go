@@ -24,3 +24,13 @@ func POrDefault[T any](ptr *T, defaultVal T) T {
 	}
 	return defaultVal
 }
+
+// FirstNonNil returns the first non-nil pointer, or nil if all are nil.
+func FirstNonNil[T any](ptrs ...*T) *T {
+	for _, p := range ptrs {
+		if p != nil {
+			return p
+		}
+	}
+	return nil
+}
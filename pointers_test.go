@@ -91,6 +91,21 @@ func TestPointerFunctions(t *testing.T) {
 			},
 			100,
 		},
+		{
+			"FirstNonNil picks first non-nil",
+			func() interface{} {
+				a, b := 1, 2
+				return *sanity.FirstNonNil(nil, &a, &b)
+			},
+			1,
+		},
+		{
+			"FirstNonNil all nil",
+			func() interface{} {
+				return sanity.FirstNonNil[int](nil, nil) == nil
+			},
+			true,
+		},
 	}
 
 	for _, tc := range testCases {
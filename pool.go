@@ -0,0 +1,28 @@
+package sanity
+
+import "sync"
+
+var guardPool = sync.Pool{New: func() any { return &Guard{max: 1} }}
+
+// AcquireGuard returns a Guard from a pool, configured by opts like NewGuard.
+// Pair it with ReleaseGuard on hot paths (e.g. per-request validation) to
+// reuse the Guard and its buffers instead of allocating them each time.
+func AcquireGuard(opts ...GuardOption) *Guard {
+	g := guardPool.Get().(*Guard)
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// ReleaseGuard resets g and returns it to the pool. Errors previously returned
+// by g.Err() stay valid, but g and its scopes must not be used afterwards.
+// Scopes and Named views are ignored.
+func ReleaseGuard(g *Guard) {
+	if g == nil || g.parent != nil {
+		return
+	}
+	g.Reset()
+	*g = Guard{max: 1, agg: g.agg, warnings: g.warnings, seen: g.seen, byCode: g.byCode}
+	guardPool.Put(g)
+}
@@ -0,0 +1,47 @@
+package sanity
+
+import "time"
+
+// Defaults and bounds applied by SanitizePool.
+const (
+	DefaultPoolMaxConns    = 10
+	DefaultPoolMaxLifetime = 30 * time.Minute
+	MinPoolMaxLifetime     = time.Second
+	MaxPoolMaxLifetime     = 24 * time.Hour
+)
+
+// PoolConfig is the usual shape of a connection pool config.
+type PoolConfig struct {
+	MinConns    int
+	MaxConns    int
+	MaxIdle     int
+	MaxLifetime time.Duration
+}
+
+// SanitizePool corrects cfg in place:
+//   - a non-positive MaxConns gets DefaultPoolMaxConns;
+//   - negative MinConns and MaxIdle become 0, and both are lowered to MaxConns
+//     if they exceed it;
+//   - a non-positive MaxLifetime gets DefaultPoolMaxLifetime, and the result is
+//     clamped to [MinPoolMaxLifetime, MaxPoolMaxLifetime].
+//
+// Like SanitizeBackoff it returns nil if nothing changed, otherwise the
+// AdjustedErrors describing each correction. A nil cfg is a no-op.
+func SanitizePool(cfg *PoolConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	g := NewGuard(WithMaxErrors(0))
+	if cfg.MaxConns <= 0 {
+		adjust(&g, "max_conns", &cfg.MaxConns, DefaultPoolMaxConns)
+	}
+	adjust(&g, "min_conns", &cfg.MinConns, min(max(cfg.MinConns, 0), cfg.MaxConns))
+	adjust(&g, "max_idle", &cfg.MaxIdle, min(max(cfg.MaxIdle, 0), cfg.MaxConns))
+	lifetime := cfg.MaxLifetime
+	if lifetime <= 0 {
+		lifetime = DefaultPoolMaxLifetime
+	}
+	ClampDuration(&lifetime, MinPoolMaxLifetime, MaxPoolMaxLifetime)
+	adjust(&g, "max_lifetime", &cfg.MaxLifetime, lifetime)
+	return g.Err()
+}
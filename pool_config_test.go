@@ -0,0 +1,88 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestSanitizePool(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Zero config gets defaults",
+			function: func() interface{} {
+				var cfg sanity.PoolConfig
+				err := sanity.SanitizePool(&cfg)
+				return []interface{}{cfg, fieldsOf(err), errors.Is(err, sanity.ErrAdjusted)}
+			},
+			expected: []interface{}{
+				sanity.PoolConfig{MaxConns: 10, MaxLifetime: 30 * time.Minute},
+				[]string{"max_conns", "max_lifetime"},
+				true,
+			},
+		},
+		{
+			name: "Sane config is untouched",
+			function: func() interface{} {
+				cfg := sanity.PoolConfig{MinConns: 2, MaxConns: 20, MaxIdle: 5, MaxLifetime: time.Hour}
+				err := sanity.SanitizePool(&cfg)
+				return []interface{}{cfg, err == nil, sanity.SanitizePool(nil) == nil}
+			},
+			expected: []interface{}{
+				sanity.PoolConfig{MinConns: 2, MaxConns: 20, MaxIdle: 5, MaxLifetime: time.Hour},
+				true,
+				true,
+			},
+		},
+		{
+			name: "Cross-field ordering enforced against MaxConns",
+			function: func() interface{} {
+				cfg := sanity.PoolConfig{MinConns: 50, MaxConns: 8, MaxIdle: 100, MaxLifetime: time.Minute}
+				err := sanity.SanitizePool(&cfg)
+				return []interface{}{cfg, fieldsOf(err)}
+			},
+			expected: []interface{}{
+				sanity.PoolConfig{MinConns: 8, MaxConns: 8, MaxIdle: 8, MaxLifetime: time.Minute},
+				[]string{"min_conns", "max_idle"},
+			},
+		},
+		{
+			name: "Negative counts and extreme lifetimes clamped",
+			function: func() interface{} {
+				short := sanity.PoolConfig{MinConns: -1, MaxConns: 4, MaxIdle: -3, MaxLifetime: time.Millisecond}
+				long := sanity.PoolConfig{MaxConns: 4, MaxLifetime: 1000 * time.Hour}
+				sanity.SanitizePool(&short)
+				sanity.SanitizePool(&long)
+				return []interface{}{short, long.MaxLifetime}
+			},
+			expected: []interface{}{
+				sanity.PoolConfig{MaxConns: 4, MaxLifetime: time.Second},
+				24 * time.Hour,
+			},
+		},
+		{
+			name: "Corrections are collected by a Guard",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0), sanity.WithFieldPrefix("db"))
+				cfg := sanity.PoolConfig{MinConns: 5, MaxConns: 2, MaxLifetime: time.Hour}
+				g.Merge(sanity.SanitizePool(&cfg))
+				return fieldsOf(g.Err())
+			},
+			expected: []string{"db.min_conns"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
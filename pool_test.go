@@ -0,0 +1,109 @@
+package sanity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestGuardPool(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Acquired Guard defaults to first-error",
+			function: func() interface{} {
+				g := sanity.AcquireGuard()
+				defer sanity.ReleaseGuard(g)
+				g.Add(sanity.NonZero("a", 0))
+				g.Add(sanity.NonZero("b", 0))
+				return g.Stats()
+			},
+			expected: sanity.MGStats{Failures: 2, Kept: 1, Dropped: 1},
+		},
+		{
+			name: "Released Guard comes back clean, options and all",
+			function: func() interface{} {
+				g := sanity.AcquireGuard(sanity.WithMaxErrors(0), sanity.WithFieldPrefix("req"), sanity.WithDedup())
+				g.Add(sanity.NonZero("a", 0))
+				g.Add(sanity.NonZero("b", 0))
+				g.AddWarning(sanity.NonZero("c", 0))
+				sanity.ReleaseGuard(g)
+				g = sanity.AcquireGuard()
+				defer sanity.ReleaseGuard(g)
+				g.Add(sanity.NonZero("x", 0))
+				g.Add(sanity.NonZero("x", 0))
+				return []interface{}{g.Stats(), fieldOf(g.Err()), g.Warnings()}
+			},
+			expected: []interface{}{sanity.MGStats{Failures: 2, Kept: 1, Dropped: 1}, "x", nil},
+		},
+		{
+			name: "Reacquired Guard without failures reports nil",
+			function: func() interface{} {
+				g := sanity.AcquireGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonZero("a", 0))
+				g.Add(sanity.NonZero("b", 0))
+				sanity.ReleaseGuard(g)
+				g = sanity.AcquireGuard(sanity.WithMaxErrors(0))
+				defer sanity.ReleaseGuard(g)
+				g.Check(sanity.NonZero("c", 1))
+				return []interface{}{g.Ok(), g.Err() == nil}
+			},
+			expected: []interface{}{true, true},
+		},
+		{
+			name: "Errors returned before release stay intact",
+			function: func() interface{} {
+				g := sanity.AcquireGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonZero("a", 0))
+				g.Add(sanity.NonZero("b", 0))
+				err := g.Err()
+				sanity.ReleaseGuard(g)
+				g = sanity.AcquireGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonZero("c", 0))
+				g.Add(sanity.NonZero("d", 0))
+				sanity.ReleaseGuard(g)
+				return fieldsOf(err)
+			},
+			expected: []string{"a", "b"},
+		},
+		{
+			name: "Reused buffer still yields a single error as is",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonZero("a", 0))
+				g.Add(sanity.NonZero("b", 0))
+				g.Reset()
+				g.Add(sanity.NonZero("c", 0))
+				_, isGroup := g.Err().(sanity.ErrorGroup)
+				return isGroup
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestGuardPoolZeroAlloc(t *testing.T) {
+	run := func() {
+		g := sanity.AcquireGuard(sanity.WithMaxErrors(4))
+		g.Check(sanity.NonEmpty("mode", "auto"))
+		g.Check(sanity.InRangeNum("port", 8080, 1, 65535))
+		sinkErr = g.Err()
+		sanity.ReleaseGuard(g)
+	}
+	run()
+	if allocs := testing.AllocsPerRun(100, run); allocs != 0 {
+		t.Errorf("expected 0 allocs per pooled OK run, got %v", allocs)
+	}
+}
@@ -0,0 +1,87 @@
+package sanity
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ProblemContentType is the RFC 7807 media type.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details object with a per-field "errors" extension.
+type Problem struct {
+	Type     string           `json:"type,omitempty"`
+	Title    string           `json:"title"`
+	Status   int              `json:"status"`
+	Detail   string           `json:"detail,omitempty"`
+	Instance string           `json:"instance,omitempty"`
+	Errors   []FieldViolation `json:"errors,omitempty"`
+}
+
+// ProblemOption customizes a Problem built by NewProblem.
+type ProblemOption func(*Problem)
+
+// WithProblemStatus overrides the status selected by StatusFor.
+func WithProblemStatus(code int) ProblemOption {
+	return func(p *Problem) {
+		p.Status = code
+		p.Title = http.StatusText(code)
+	}
+}
+
+// WithProblemType sets the problem type URI (default "about:blank").
+func WithProblemType(uri string) ProblemOption {
+	return func(p *Problem) { p.Type = uri }
+}
+
+// WithProblemDetail sets the human-readable detail.
+func WithProblemDetail(detail string) ProblemOption {
+	return func(p *Problem) { p.Detail = detail }
+}
+
+// WithProblemInstance sets the URI identifying this occurrence (e.g. the request path).
+func WithProblemInstance(uri string) ProblemOption {
+	return func(p *Problem) { p.Instance = uri }
+}
+
+// StatusFor selects an HTTP status for err: 200 for nil, 500 for recovered
+// panics, 503 for aborted runs, 422 when any member is a FieldError, 400 otherwise.
+func StatusFor(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	if errors.Is(err, ErrPanic) {
+		return http.StatusInternalServerError
+	}
+	if errors.Is(err, ErrAborted) {
+		return http.StatusServiceUnavailable
+	}
+	var fe FieldError
+	if errors.As(err, &fe) {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+// NewProblem converts err (typically Guard.Err()) into a Problem with one
+// "errors" entry per aggregate member.
+func NewProblem(err error, opts ...ProblemOption) Problem {
+	status := StatusFor(err)
+	p := Problem{Type: "about:blank", Title: http.StatusText(status), Status: status}
+	for _, e := range GroupAsSlice(err, nil) {
+		p.Errors = append(p.Errors, violationOf(e))
+	}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return p
+}
+
+// WriteProblem writes NewProblem(err, opts...) as application/problem+json.
+func WriteProblem(w http.ResponseWriter, err error, opts ...ProblemOption) error {
+	p := NewProblem(err, opts...)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
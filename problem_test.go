@@ -0,0 +1,88 @@
+package sanity_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestProblem(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "StatusFor selection",
+			function: func() interface{} {
+				return []int{
+					sanity.StatusFor(nil),
+					sanity.StatusFor(sanity.NonZero("n", 0)),
+					sanity.StatusFor(errors.New("bad json")),
+					sanity.StatusFor(sanity.CatchPanic(func() error { panic("x") })),
+				}
+			},
+			expected: []int{200, 422, 400, 500},
+		},
+		{
+			name: "NewProblem lists members as field errors",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.NonEmpty("name", ""))
+				g.Add(sanity.NonZero("port", 0))
+				return sanity.NewProblem(g.Err(), sanity.WithProblemInstance("/v1/servers"))
+			},
+			expected: sanity.Problem{
+				Type:     "about:blank",
+				Title:    "Unprocessable Entity",
+				Status:   422,
+				Instance: "/v1/servers",
+				Errors: []sanity.FieldViolation{
+					{Field: "name", Code: "NON_EMPTY", Message: "must be non-empty"},
+					{Field: "port", Code: "NON_ZERO", Message: "must be non-zero"},
+				},
+			},
+		},
+		{
+			name: "Allowed values reach the payload",
+			function: func() interface{} {
+				return sanity.NewProblem(sanity.OneOf("mode", "x", "auto", "manual")).Errors
+			},
+			expected: []sanity.FieldViolation{
+				{Field: "mode", Code: "NOT_IN_SET", Message: "must be one of [auto, manual]", Allowed: []string{"auto", "manual"}},
+			},
+		},
+		{
+			name: "Status override updates title",
+			function: func() interface{} {
+				p := sanity.NewProblem(sanity.NonZero("n", 0), sanity.WithProblemStatus(400),
+					sanity.WithProblemType("https://example.com/probs/validation"), sanity.WithProblemDetail("bad input"))
+				return []interface{}{p.Status, p.Title, p.Type, p.Detail}
+			},
+			expected: []interface{}{400, "Bad Request", "https://example.com/probs/validation", "bad input"},
+		},
+		{
+			name: "WriteProblem sets media type, status and body",
+			function: func() interface{} {
+				rec := httptest.NewRecorder()
+				_ = sanity.WriteProblem(rec, sanity.NonEmpty("mode", ""))
+				var body map[string]any
+				_ = json.Unmarshal(rec.Body.Bytes(), &body)
+				return []interface{}{rec.Code, rec.Header().Get("Content-Type"), body["status"], len(body["errors"].([]any))}
+			},
+			expected: []interface{}{422, "application/problem+json", float64(422), 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package sanity
+
+import "time"
+
+// ProtoWrapper is satisfied by pointers to the protobuf wrapper types
+// (*wrapperspb.Int32Value, *wrapperspb.StringValue, ...) without importing
+// them. A nil wrapper means "unset".
+type ProtoWrapper[T any] interface {
+	comparable
+	GetValue() T
+}
+
+// ProtoDuration is satisfied by *durationpb.Duration.
+type ProtoDuration interface {
+	comparable
+	AsDuration() time.Duration
+}
+
+// WrapperOr returns w's value, or def if w is nil. Untyped constants default
+// to int, so def may need a conversion:
+//
+//	limit := sanity.WrapperOr(req.Limit, int32(20))
+func WrapperOr[W ProtoWrapper[T], T any](w W, def T) T {
+	var unset W
+	if w == unset {
+		return def
+	}
+	return w.GetValue()
+}
+
+// WrapperClamp returns w's value, or def if w is nil, clamped into [min,max].
+func WrapperClamp[W ProtoWrapper[T], T Numeric](w W, def, min, max T) T {
+	v := WrapperOr(w, def)
+	Clamp(&v, min, max)
+	return v
+}
+
+// WrapperRequired reports a nil (unset) wrapper as a NotNilError.
+func WrapperRequired[W comparable](name string, w W) error {
+	var unset W
+	if w == unset {
+		return NotNilError{Field: name}
+	}
+	return nil
+}
+
+// WrapperInRange checks that a set wrapper holds a value in [min,max]; an
+// unset wrapper passes (combine with WrapperRequired to reject it).
+func WrapperInRange[W ProtoWrapper[T], T Numeric](name string, w W, min, max T) error {
+	var unset W
+	if w == unset {
+		return nil
+	}
+	return InRangeNum(name, w.GetValue(), min, max)
+}
+
+// ProtoDurationOr returns d as a time.Duration, or def if d is nil.
+func ProtoDurationOr[D ProtoDuration](d D, def time.Duration) time.Duration {
+	var unset D
+	if d == unset {
+		return def
+	}
+	return d.AsDuration()
+}
+
+// ProtoDurationClamp returns d as a time.Duration, or def if d is nil,
+// clamped into [min,max].
+func ProtoDurationClamp[D ProtoDuration](d D, def, min, max time.Duration) time.Duration {
+	v := ProtoDurationOr(d, def)
+	ClampDuration(&v, min, max)
+	return v
+}
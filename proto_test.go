@@ -0,0 +1,104 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+// Stand-ins for wrapperspb.Int32Value, wrapperspb.StringValue and
+// durationpb.Duration, with the same nil-safe accessors.
+type int32Value struct{ Value int32 }
+
+func (x *int32Value) GetValue() int32 {
+	if x == nil {
+		return 0
+	}
+	return x.Value
+}
+
+type stringValue struct{ Value string }
+
+func (x *stringValue) GetValue() string {
+	if x == nil {
+		return ""
+	}
+	return x.Value
+}
+
+type durationValue struct{ Seconds int64 }
+
+func (x *durationValue) AsDuration() time.Duration {
+	if x == nil {
+		return 0
+	}
+	return time.Duration(x.Seconds) * time.Second
+}
+
+func TestProtoWrappers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "WrapperOr distinguishes unset from zero",
+			function: func() interface{} {
+				var unset *int32Value
+				return []interface{}{
+					sanity.WrapperOr(unset, int32(20)),
+					sanity.WrapperOr(&int32Value{}, int32(20)),
+					sanity.WrapperOr(&stringValue{Value: "eu"}, "us"),
+				}
+			},
+			expected: []interface{}{int32(20), int32(0), "eu"},
+		},
+		{
+			name: "WrapperClamp",
+			function: func() interface{} {
+				var unset *int32Value
+				return []int32{
+					sanity.WrapperClamp(&int32Value{Value: 5000}, 20, 1, 100),
+					sanity.WrapperClamp(unset, 200, 1, 100),
+				}
+			},
+			expected: []int32{100, 100},
+		},
+		{
+			name: "Validators treat nil as unset",
+			function: func() interface{} {
+				var unset *int32Value
+				err := sanity.WrapperInRange("limit", &int32Value{Value: 0}, 1, 100)
+				return []interface{}{
+					sanity.WrapperInRange("limit", unset, 1, 100) == nil,
+					errors.Is(err, sanity.ErrOutOfRange),
+					errors.Is(sanity.WrapperRequired("limit", unset), sanity.ErrNotNil),
+					sanity.WrapperRequired("limit", &int32Value{}) == nil,
+				}
+			},
+			expected: []interface{}{true, true, true, true},
+		},
+		{
+			name: "ProtoDurationOr and ProtoDurationClamp",
+			function: func() interface{} {
+				var unset *durationValue
+				return []time.Duration{
+					sanity.ProtoDurationOr(unset, time.Second),
+					sanity.ProtoDurationOr(&durationValue{Seconds: 3}, time.Second),
+					sanity.ProtoDurationClamp(&durationValue{Seconds: 3600}, time.Second, 0, time.Minute),
+				}
+			},
+			expected: []time.Duration{time.Second, 3 * time.Second, time.Minute},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
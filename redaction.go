@@ -0,0 +1,38 @@
+package sanity
+
+import "sync/atomic"
+
+// RedactionMode selects whether error strings include offending values.
+type RedactionMode int32
+
+const (
+	RedactNone   RedactionMode = iota // include "got ..." details (default)
+	RedactValues                      // omit offending values
+)
+
+var redactionMode atomic.Int32
+
+// SetRedaction sets the process-wide redaction mode. Builds with -tags=redact
+// always redact, regardless of the mode.
+func SetRedaction(m RedactionMode) { redactionMode.Store(int32(m)) }
+
+// CurrentRedaction returns the process-wide redaction mode.
+func CurrentRedaction() RedactionMode { return RedactionMode(redactionMode.Load()) }
+
+// redacting reports whether Error() strings must omit offending values.
+func redacting() bool {
+	return RedactBuild || CurrentRedaction() == RedactValues
+}
+
+// WithRedaction makes the Guard record errors whose messages omit offending
+// values; the values stay reachable via errors.As (e.g. RangeError.Value()).
+func WithRedaction() GuardOption {
+	return func(g *Guard) { g.redact = true }
+}
+
+// Redacted returns err with offending values omitted from its messages
+// (aggregates member by member), so one error can be logged verbosely and
+// sent to clients redacted.
+func Redacted(err error) error {
+	return render(err, RenderOptions{Redact: true})
+}
@@ -0,0 +1,77 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestRuntimeRedaction(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "SetRedaction hides values process-wide",
+			function: func() interface{} {
+				sanity.SetRedaction(sanity.RedactValues)
+				defer sanity.SetRedaction(sanity.RedactNone)
+				return []string{
+					sanity.InRangeNum("port", 0, 1, 10).Error(),
+					sanity.StrLenAtLeast("name", "a", 3).Error(),
+					sanity.WithPrefix("db", sanity.InRangeNum("pool", 0, 1, 4)).Error(),
+				}
+			},
+			expected: []string{"port: must be in [1,10]", "name: len must be >= 3", "db.pool: must be in [1,4]"},
+		},
+		{
+			name: "WithRedaction redacts only that Guard, values stay reachable",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithRedaction())
+				g.Add(sanity.InRangeNum("port", 0, 1, 10))
+				err := g.Err()
+				var re sanity.RangeError
+				ok := errors.As(err, &re)
+				return []interface{}{err.Error(), ok && re.Value() == 0, errors.Is(err, sanity.ErrOutOfRange)}
+			},
+			expected: []interface{}{"port: must be in [1,10]", true, true},
+		},
+		{
+			name: "WithRedaction composes with scopes",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithRedaction(), sanity.WithMaxErrors(0))
+				g.Scope("srv").Add(sanity.InRangeNum("port", 0, 1, 10))
+				p := sanity.NewProblem(g.Err())
+				return p.Errors
+			},
+			expected: []sanity.FieldViolation{{Field: "srv.port", Code: "OUT_OF_RANGE", Message: "must be in [1,10]"}},
+		},
+		{
+			name: "Redacted for clients, original for logs",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.InRangeNum("a", 5, 1, 3))
+				g.Add(sanity.NonZero("b", 0))
+				err := g.Err()
+				var msgs []interface{}
+				for _, e := range sanity.GroupAsSlice(sanity.Redacted(err), nil) {
+					msgs = append(msgs, e.Error())
+				}
+				orig := sanity.GroupAsSlice(err, nil)[0].Error()
+				return append(msgs, orig == "a: must be in [1,3], got 5" || sanity.RedactBuild)
+			},
+			expected: []interface{}{"a: must be in [1,3]", "b: must be non-zero", true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
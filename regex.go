@@ -0,0 +1,109 @@
+package sanity
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// Pattern sentinels (for errors.Is).
+var (
+	ErrPatternMismatch = errors.New("sanity:pattern_mismatch")
+	ErrBadPattern      = errors.New("sanity:bad_pattern")
+)
+
+// PatternError indicates a string that does not match Pattern.
+type PatternError struct {
+	Field   string
+	Pattern string
+	Got     string
+}
+
+func (e PatternError) Unwrap() error     { return ErrPatternMismatch }
+func (e PatternError) FieldName() string { return e.Field }
+func (e PatternError) Code() string      { return CodePatternMismatch }
+func (e PatternError) Error() string     { return e.FieldName() + ": " + e.message(redacting()) }
+func (e PatternError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e PatternError) message(redact bool) string {
+	if redact {
+		return fmt.Sprintf("must match %q", e.Pattern)
+	}
+	return fmt.Sprintf("must match %q, got %q", e.Pattern, e.Got)
+}
+
+// MatchesRegex checks that s matches pattern. Compiled patterns are kept in a
+// small LRU cache, so calling it in a loop does not recompile. An invalid
+// pattern yields an error matching ErrBadPattern.
+func MatchesRegex(name, s, pattern string) error {
+	re, err := regexCache.get(pattern)
+	if err != nil {
+		return FieldPathError{Path: name, Err: fmt.Errorf("pattern %q: %v: %w", pattern, err, ErrBadPattern)}
+	}
+	return MatchesCompiled(name, s, re)
+}
+
+// MatchesCompiled checks that s matches re.
+func MatchesCompiled(name, s string, re *regexp.Regexp) error {
+	if !re.MatchString(s) {
+		return PatternError{Field: name, Pattern: re.String(), Got: s}
+	}
+	return nil
+}
+
+const regexCacheSize = 128
+
+var regexCache = newRegexLRU(regexCacheSize)
+
+// regexLRU caches compile results (including failures) by pattern.
+type regexLRU struct {
+	mu    sync.Mutex
+	max   int
+	order *list.List // front = most recently used
+	items map[string]*list.Element
+}
+
+type regexEntry struct {
+	pattern string
+	re      *regexp.Regexp
+	err     error
+}
+
+func newRegexLRU(max int) *regexLRU {
+	return &regexLRU{max: max, order: list.New(), items: make(map[string]*list.Element, max)}
+}
+
+func (c *regexLRU) get(pattern string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	if el, ok := c.items[pattern]; ok {
+		c.order.MoveToFront(el)
+		e := el.Value.(*regexEntry)
+		c.mu.Unlock()
+		return e.re, e.err
+	}
+	c.mu.Unlock()
+
+	// Compile outside the lock; a concurrent miss on the same pattern just
+	// compiles twice.
+	re, err := regexp.Compile(pattern)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[pattern]; ok {
+		c.order.MoveToFront(el)
+		e := el.Value.(*regexEntry)
+		return e.re, e.err
+	}
+	c.items[pattern] = c.order.PushFront(&regexEntry{pattern: pattern, re: re, err: err})
+	if c.order.Len() > c.max {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*regexEntry).pattern)
+	}
+	return re, err
+}
@@ -0,0 +1,97 @@
+package sanity_test
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestRegex(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "MatchesRegex",
+			function: func() interface{} {
+				return []bool{
+					sanity.MatchesRegex("slug", "my-app-1", `^[a-z0-9-]+$`) == nil,
+					errors.Is(sanity.MatchesRegex("slug", "My App", `^[a-z0-9-]+$`), sanity.ErrPatternMismatch),
+				}
+			},
+			expected: []bool{true, true},
+		},
+		{
+			name: "MatchesCompiled",
+			function: func() interface{} {
+				re := regexp.MustCompile(`^v\d+$`)
+				return []bool{
+					sanity.MatchesCompiled("api", "v2", re) == nil,
+					errors.Is(sanity.MatchesCompiled("api", "2", re), sanity.ErrPatternMismatch),
+				}
+			},
+			expected: []bool{true, true},
+		},
+		{
+			name: "PatternError fields and messages",
+			function: func() interface{} {
+				err := sanity.MatchesRegex("slug", "A", `^[a-z]+$`)
+				var pe sanity.PatternError
+				return []interface{}{
+					errors.As(err, &pe), pe.Pattern, pe.Got, sanity.CodeOf(err),
+					sanity.Redacted(err).Error(),
+				}
+			},
+			expected: []interface{}{true, "^[a-z]+$", "A", "PATTERN_MISMATCH", `slug: must match "^[a-z]+$"`},
+		},
+		{
+			name: "Invalid pattern reports ErrBadPattern with the field",
+			function: func() interface{} {
+				err := sanity.MatchesRegex("slug", "x", `[`)
+				var fe sanity.FieldError
+				return []interface{}{
+					errors.Is(err, sanity.ErrBadPattern), errors.Is(err, sanity.ErrPatternMismatch),
+					errors.As(err, &fe) && fe.FieldName() == "slug",
+					errors.Is(sanity.MatchesRegex("slug", "x", `[`), sanity.ErrBadPattern),
+				}
+			},
+			expected: []interface{}{true, false, true, true},
+		},
+		{
+			name: "Evicted patterns are recompiled correctly",
+			function: func() interface{} {
+				for i := 0; i < 300; i++ {
+					if err := sanity.MatchesRegex("n", fmt.Sprint(i), fmt.Sprintf("^%d$", i)); err != nil {
+						return err
+					}
+				}
+				return sanity.MatchesRegex("n", "0", "^0$")
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestMatchesRegexCachedZeroAlloc(t *testing.T) {
+	const pattern = `^[a-z0-9-]+$`
+	_ = sanity.MatchesRegex("slug", "warm-up", pattern)
+	allocs := testing.AllocsPerRun(100, func() {
+		sinkErr = sanity.MatchesRegex("slug", "my-app-1", pattern)
+	})
+	if allocs != 0 {
+		t.Errorf("expected 0 allocs on a cached pattern, got %v", allocs)
+	}
+}
@@ -0,0 +1,128 @@
+package sanity
+
+import "context"
+
+// RenderOptions controls how error messages are rendered for a request.
+type RenderOptions struct {
+	Locale string // BCP 47 tag, e.g. "fr"; "" means the default (English); see SetTranslator
+	Redact bool   // omit offending values ("got ...") from messages
+}
+
+// RenderOption configures RenderOptions.
+type RenderOption func(*RenderOptions)
+
+// Locale selects the message language.
+func Locale(lang string) RenderOption {
+	return func(o *RenderOptions) { o.Locale = lang }
+}
+
+// Redact omits offending values from messages.
+func Redact() RenderOption {
+	return func(o *RenderOptions) { o.Redact = true }
+}
+
+type renderKey struct{}
+
+// WithOptions returns a child context carrying opts, applied on top of any
+// options already present in ctx.
+func WithOptions(ctx context.Context, opts ...RenderOption) context.Context {
+	o, _ := ctx.Value(renderKey{}).(RenderOptions)
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return context.WithValue(ctx, renderKey{}, o)
+}
+
+// WithLocale is shorthand for WithOptions(ctx, Locale(lang)).
+func WithLocale(ctx context.Context, lang string) context.Context {
+	return WithOptions(ctx, Locale(lang))
+}
+
+// OptionsFrom returns the render options carried by ctx.
+func OptionsFrom(ctx context.Context) (RenderOptions, bool) {
+	o, ok := ctx.Value(renderKey{}).(RenderOptions)
+	return o, ok
+}
+
+// ErrCtx is like Err, but renders messages using the options carried by ctx.
+func (gd *Guard) ErrCtx(ctx context.Context) error {
+	return RenderCtx(ctx, gd.Err())
+}
+
+// RenderCtx returns err with its messages rendered using the options carried
+// by ctx. Aggregates are rendered member by member. errors.Is/As still reach
+// the original errors. Without options in ctx, err is returned unchanged.
+func RenderCtx(ctx context.Context, err error) error {
+	o, ok := OptionsFrom(ctx)
+	if !ok {
+		return err
+	}
+	return render(err, o)
+}
+
+func render(err error, o RenderOptions) error {
+	if err == nil {
+		return nil
+	}
+	if eg, ok := err.(ErrorGroup); ok {
+		out := groupLike(err)
+		eg.Iter(func(e error) bool {
+			out.push(renderWith(e, o))
+			return true
+		})
+		return out
+	}
+	return renderWith(err, o)
+}
+
+// renderWith wraps err, merging with options of an already rendered error so
+// wrappers don't nest (the outer Locale wins; redaction is sticky).
+func renderWith(err error, o RenderOptions) error {
+	if r, ok := err.(renderedError); ok {
+		if o.Locale == "" {
+			o.Locale = r.opts.Locale
+		}
+		o.Redact = o.Redact || r.opts.Redact
+		err = r.err
+	}
+	return renderedError{err: err, opts: o}
+}
+
+// renderedError overrides the message of err according to opts.
+type renderedError struct {
+	err  error
+	opts RenderOptions
+}
+
+func (e renderedError) Error() string { return renderMessage(e.err, e.opts) }
+func (e renderedError) Unwrap() error { return e.err }
+
+func (e renderedError) message(redact bool) string {
+	o := e.opts
+	o.Redact = o.Redact || redact
+	return messageOf(e.err, o)
+}
+
+func renderMessage(err error, o RenderOptions) string {
+	if _, ok := err.(messager); !ok {
+		return err.Error()
+	}
+	msg := messageOf(err, o)
+	if fe, ok := err.(FieldError); ok {
+		return fe.FieldName() + ": " + msg
+	}
+	return msg
+}
+
+// messageOf renders the message of err (without the field prefix), localized
+// when o.Locale is set and a translator knows it.
+func messageOf(err error, o RenderOptions) string {
+	o.Redact = o.Redact || redacting()
+	if msg, ok := translate(err, o); ok {
+		return msg
+	}
+	if m, ok := err.(messager); ok {
+		return m.message(o.Redact)
+	}
+	return err.Error()
+}
@@ -0,0 +1,81 @@
+package sanity_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestRenderCtx(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "No options in ctx -> unchanged error",
+			function: func() interface{} {
+				e := sanity.NonZero("n", 0)
+				return sanity.RenderCtx(context.Background(), e) == e
+			},
+			expected: true,
+		},
+		{
+			name: "Options accumulate across WithOptions calls",
+			function: func() interface{} {
+				ctx := sanity.WithLocale(context.Background(), "fr")
+				ctx = sanity.WithOptions(ctx, sanity.Redact())
+				o, ok := sanity.OptionsFrom(ctx)
+				return []interface{}{ok, o}
+			},
+			expected: []interface{}{true, sanity.RenderOptions{Locale: "fr", Redact: true}},
+		},
+		{
+			name: "Redact option hides value per request",
+			function: func() interface{} {
+				ctx := sanity.WithOptions(context.Background(), sanity.Redact())
+				g := sanity.NewGuard()
+				g.Add(sanity.InRangeNum("port", 0, 1, 10))
+				err := g.ErrCtx(ctx)
+				return []interface{}{err.Error(), errors.Is(err, sanity.ErrOutOfRange)}
+			},
+			expected: []interface{}{"port: must be in [1,10]", true},
+		},
+		{
+			name: "Aggregate members rendered individually",
+			function: func() interface{} {
+				ctx := sanity.WithOptions(context.Background(), sanity.Redact())
+				g := sanity.NewGuard(sanity.WithMaxErrors(0))
+				g.Add(sanity.StrLenAtLeast("name", "a", 3))
+				g.Add(sanity.WithPrefix("db", errors.New("timeout")))
+				var msgs []string
+				for _, e := range sanity.GroupAsSlice(g.ErrCtx(ctx), nil) {
+					msgs = append(msgs, e.Error())
+				}
+				return msgs
+			},
+			expected: []string{"name: len must be >= 3", "db: timeout"},
+		},
+		{
+			name: "Rendered errors still expose typed errors via As",
+			function: func() interface{} {
+				ctx := sanity.WithLocale(context.Background(), "de")
+				err := sanity.RenderCtx(ctx, sanity.InRangeNum("n", 5, 1, 3))
+				var re sanity.RangeError
+				return errors.As(err, &re) && re.Value() == 5
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
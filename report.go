@@ -0,0 +1,143 @@
+package sanity
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// ReportOption configures Report.
+type ReportOption func(*reportOptions)
+
+type reportOptions struct {
+	color bool
+	html  bool
+}
+
+// ReportColor highlights the plain-text report with ANSI escape codes, for
+// terminals.
+func ReportColor() ReportOption {
+	return func(o *reportOptions) { o.color = true }
+}
+
+// ReportHTML renders the report as an HTML fragment with escaped messages
+// instead of plain text; ReportColor is then ignored.
+func ReportHTML() ReportOption {
+	return func(o *reportOptions) { o.html = true }
+}
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiDim   = "\x1b[2m"
+	ansiRed   = "\x1b[1;31m"
+)
+
+// Report renders the members of err (see Errors) as a multi-line report for
+// CLI tools: a summary line, then the members grouped by field in order of
+// first appearance and numbered, each with its code, and a final line for
+// members dropped by a cap. Members without a field are grouped under
+// "(no field)". A nil err yields "".
+//
+//	3 errors in 2 fields
+//	port
+//	  1. must be in [1,65535], got 0 [OUT_OF_RANGE]
+//	db.host
+//	  2. must be non-empty [NON_EMPTY]
+//	  3. must be non-zero [NON_ZERO]
+func Report(err error, opts ...ReportOption) string {
+	if err == nil {
+		return ""
+	}
+	var o reportOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var (
+		fields  []string
+		byField = make(map[string][]FieldViolation)
+		total   int
+		dropped int
+	)
+	for e := range Errors(err) {
+		if c, ok := e.(ErrorsClampedError); ok {
+			dropped += c.Dropped
+			continue
+		}
+		v := violationOf(e)
+		if _, seen := byField[v.Field]; !seen {
+			fields = append(fields, v.Field)
+		}
+		byField[v.Field] = append(byField[v.Field], v)
+		total++
+	}
+	summary := plural(total, "error") + " in " + plural(len(fields), "field")
+	more := ""
+	if dropped > 0 {
+		more = strconv.Itoa(dropped) + " more dropped (cap reached)"
+	}
+	if o.html {
+		return reportHTML(summary, more, fields, byField)
+	}
+	style := func(code, s string) string {
+		if !o.color {
+			return s
+		}
+		return code + s + ansiReset
+	}
+	var b strings.Builder
+	b.WriteString(style(ansiRed, summary))
+	n := 0
+	for _, f := range fields {
+		b.WriteString("\n" + style(ansiBold, fieldLabel(f)))
+		for _, v := range byField[f] {
+			n++
+			b.WriteString("\n  " + strconv.Itoa(n) + ". " + v.Message)
+			if v.Code != "" {
+				b.WriteString(" " + style(ansiDim, "["+v.Code+"]"))
+			}
+		}
+	}
+	if more != "" {
+		b.WriteString("\n" + style(ansiDim, more))
+	}
+	return b.String()
+}
+
+func reportHTML(summary, more string, fields []string, byField map[string][]FieldViolation) string {
+	var b strings.Builder
+	b.WriteString(`<div class="sanity-report"><p>` + summary + "</p><dl>")
+	n := 1
+	for _, f := range fields {
+		b.WriteString("<dt>" + html.EscapeString(fieldLabel(f)) + `</dt><dd><ol start="` + strconv.Itoa(n) + `">`)
+		for _, v := range byField[f] {
+			b.WriteString("<li>" + html.EscapeString(v.Message))
+			if v.Code != "" {
+				b.WriteString(" <code>" + html.EscapeString(v.Code) + "</code>")
+			}
+			b.WriteString("</li>")
+			n++
+		}
+		b.WriteString("</ol></dd>")
+	}
+	b.WriteString("</dl>")
+	if more != "" {
+		b.WriteString("<p>" + more + "</p>")
+	}
+	b.WriteString("</div>")
+	return b.String()
+}
+
+func fieldLabel(f string) string {
+	if f == "" {
+		return "(no field)"
+	}
+	return f
+}
+
+func plural(n int, noun string) string {
+	if n != 1 {
+		noun += "s"
+	}
+	return strconv.Itoa(n) + " " + noun
+}
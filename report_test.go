@@ -0,0 +1,68 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func reportErr() error {
+	g := sanity.NewGuard(sanity.WithMaxErrors(4))
+	g.Add(sanity.OneOf("mode", "x", "auto", "manual"))
+	g.Add(sanity.NonEmpty("db.host", ""))
+	g.Add(errors.New("config: <unreadable>"))
+	g.Add(sanity.NonZero("db.host", ""))
+	g.Add(sanity.NonZero("dropped", 0))
+	return g.Err()
+}
+
+func TestReport(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name:     "Plain text groups members by field and numbers them",
+			function: func() interface{} { return sanity.Report(reportErr()) },
+			expected: "4 errors in 3 fields\n" +
+				"mode\n" +
+				"  1. must be one of [auto, manual] [NOT_IN_SET]\n" +
+				"db.host\n" +
+				"  2. must be non-empty [NON_EMPTY]\n" +
+				"  3. must be non-zero [NON_ZERO]\n" +
+				"(no field)\n" +
+				"  4. config: <unreadable>\n" +
+				"1 more dropped (cap reached)",
+		},
+		{
+			name:     "ANSI colors",
+			function: func() interface{} { return sanity.Report(sanity.NonZero("n", 0), sanity.ReportColor()) },
+			expected: "\x1b[1;31m1 error in 1 field\x1b[0m\n\x1b[1mn\x1b[0m\n  1. must be non-zero \x1b[2m[NON_ZERO]\x1b[0m",
+		},
+		{
+			name: "HTML escapes messages",
+			function: func() interface{} {
+				return sanity.Report(sanity.Collect(sanity.NonZero("a", 0), errors.New("<b>")), sanity.ReportHTML(), sanity.ReportColor())
+			},
+			expected: `<div class="sanity-report"><p>2 errors in 2 fields</p><dl>` +
+				`<dt>a</dt><dd><ol start="1"><li>must be non-zero <code>NON_ZERO</code></li></ol></dd>` +
+				`<dt>(no field)</dt><dd><ol start="2"><li>&lt;b&gt;</li></ol></dd></dl></div>`,
+		},
+		{
+			name:     "Nil error",
+			function: func() interface{} { return sanity.Report(nil) },
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package sanity
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Rules is a declarative set of per-field rules, defined once and applied to
+// maps (map[string]T, e.g. decoded JSON) or structs. Rules use the vocabulary
+// of `sanity` struct tags (see ValidateStruct), so they can also be loaded from
+// config with Tag or RulesFromTags:
+//
+//	r := sanity.NewRules()
+//	r.Field("port").Required().NonZero().InRange(1, 65535)
+//	r.Field("mode").InSet("auto", "manual")
+//	err := r.Validate(values)
+type Rules struct {
+	order  []string
+	fields map[string]*FieldRules
+}
+
+// FieldRules are the rules of one field; its methods add rules and return the
+// receiver for chaining.
+type FieldRules struct {
+	parts    []string
+	required bool
+}
+
+// NewRules returns an empty rule set.
+func NewRules() *Rules {
+	return &Rules{fields: make(map[string]*FieldRules)}
+}
+
+// RulesFromTags builds Rules from tag strings keyed by field name, e.g.
+// {"port": "required,nonzero,min=1,max=65535"}, applied in name order. Rule syntax is
+// checked when the rules are applied, against each value's type.
+func RulesFromTags(tags map[string]string) *Rules {
+	r := NewRules()
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		r.Field(name).Tag(tags[name])
+	}
+	return r
+}
+
+// Field returns the rules of field name, registering it on first use. Fields
+// are validated in registration order.
+func (r *Rules) Field(name string) *FieldRules {
+	if f, ok := r.fields[name]; ok {
+		return f
+	}
+	f := &FieldRules{}
+	r.fields[name] = f
+	r.order = append(r.order, name)
+	return f
+}
+
+// Tag adds rules written as a `sanity` struct tag ("nonzero,min=1"). A
+// "required" part is understood too and acts like Required, so rules loaded
+// from config can mark keys that must be present.
+func (f *FieldRules) Tag(spec string) *FieldRules {
+	for _, part := range strings.Split(spec, ",") {
+		switch part = strings.TrimSpace(part); part {
+		case "":
+		case "required":
+			f.required = true
+		default:
+			f.parts = append(f.parts, part)
+		}
+	}
+	return f
+}
+
+// Required makes a missing map key a MissingKeyError. Without it, rules of a
+// missing key are skipped.
+func (f *FieldRules) Required() *FieldRules {
+	f.required = true
+	return f
+}
+
+func (f *FieldRules) NotNil() *FieldRules   { return f.Tag("notnil") }
+func (f *FieldRules) NonZero() *FieldRules  { return f.Tag("nonzero") }
+func (f *FieldRules) NonEmpty() *FieldRules { return f.Tag("nonempty") }
+func (f *FieldRules) NonBlank() *FieldRules { return f.Tag("nonblank") }
+
+// MinLen requires len >= n.
+func (f *FieldRules) MinLen(n int) *FieldRules { return f.Tag("minlen=" + strconv.Itoa(n)) }
+
+// Min and Max bound numeric values (inclusive). Bounds are formatted with %v,
+// so time.Duration bounds work for duration fields.
+func (f *FieldRules) Min(v any) *FieldRules { return f.Tag(fmt.Sprint("min=", v)) }
+func (f *FieldRules) Max(v any) *FieldRules { return f.Tag(fmt.Sprint("max=", v)) }
+
+// InRange is Min(min).Max(max).
+func (f *FieldRules) InRange(min, max any) *FieldRules { return f.Min(min).Max(max) }
+
+// InSet requires the value, formatted with %v, to be one of values.
+func (f *FieldRules) InSet(values ...string) *FieldRules {
+	return f.Tag("oneof=" + strings.Join(values, "|"))
+}
+
+// Validate applies the rules to v, a map with string keys or a struct (or
+// pointer to one), and returns the aggregate of all failures (unlimited by
+// default; opts configure the Guard). As with ValidateStruct only the first
+// failing rule of each field is reported. Struct fields are matched by json
+// name or Go name.
+//
+// Malformed rules, rules naming a struct field that does not exist and
+// unsupported targets are programming errors, returned immediately
+// (ErrBadTag, ErrNotStruct).
+func (r *Rules) Validate(v any, opts ...GuardOption) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	isMap := rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String
+	if !isMap && rv.Kind() != reflect.Struct {
+		return fmt.Errorf("sanity: Rules.Validate(%T): want map with string keys or struct: %w", v, ErrNotStruct)
+	}
+	g := NewGuard(append([]GuardOption{WithMaxErrors(0)}, opts...)...)
+	for _, name := range r.order {
+		f := r.fields[name]
+		var fv reflect.Value
+		if isMap {
+			fv = rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+			if !fv.IsValid() {
+				if f.required {
+					g.Add(MissingKeyError{Field: name})
+				}
+				continue
+			}
+		} else if fv = structField(rv, name); !fv.IsValid() {
+			return fmt.Errorf("sanity: Rules: %s has no field %q: %w", rv.Type(), name, ErrBadTag)
+		}
+		violation, err := f.check(name, fv)
+		if err != nil {
+			return err
+		}
+		g.Add(violation)
+	}
+	return g.Err()
+}
+
+// check applies f to fv and returns its violation, or err for a malformed rule.
+func (f *FieldRules) check(name string, fv reflect.Value) (violation, err error) {
+	for fv.Kind() == reflect.Interface && !fv.IsNil() {
+		fv = fv.Elem()
+	}
+	spec := strings.Join(f.parts, ",")
+	if fv.Kind() == reflect.Interface { // nil entry of a map[string]any: only presence rules apply
+		for _, part := range strings.Split(spec, ",") {
+			switch strings.TrimSpace(part) {
+			case "notnil":
+				return NotNilError{Field: name}, nil
+			case "nonzero":
+				return NonZeroError{Field: name}, nil
+			}
+		}
+		return nil, nil
+	}
+	r := fieldRule{minLen: -1}
+	if err := r.parse(spec, fv.Type()); err != nil {
+		return nil, fmt.Errorf("sanity: Rules: %s: %w", name, err)
+	}
+	return r.check(name, fv), nil
+}
+
+// structField returns the exported field of rv named name by its json name or
+// Go name, or the zero Value.
+func structField(rv reflect.Value, name string) reflect.Value {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.IsExported() && (fieldNameOf(sf) == name || sf.Name == name) {
+			return rv.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
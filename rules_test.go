@@ -0,0 +1,119 @@
+package sanity_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func serverRules() *sanity.Rules {
+	r := sanity.NewRules()
+	r.Field("port").Required().NonZero().InRange(1, 65535)
+	r.Field("mode").InSet("auto", "manual")
+	r.Field("timeout").InRange(time.Second, time.Minute)
+	return r
+}
+
+func TestRules(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Valid map -> nil",
+			function: func() interface{} {
+				return serverRules().Validate(map[string]any{"port": 80, "mode": "auto"}) == nil
+			},
+			expected: true,
+		},
+		{
+			name: "Every failing key reported in registration order",
+			function: func() interface{} {
+				return fieldsOf(serverRules().Validate(map[string]any{"timeout": time.Hour, "mode": "x", "port": 70000}))
+			},
+			expected: []string{"port", "mode", "timeout"},
+		},
+		{
+			name: "Decoded JSON numbers checked as floats",
+			function: func() interface{} {
+				var m map[string]any
+				_ = json.Unmarshal([]byte(`{"port": 0, "mode": "manual"}`), &m)
+				err := serverRules().Validate(m)
+				return []bool{errors.Is(err, sanity.ErrNonZero), errors.Is(err, sanity.ErrNotInSet)}
+			},
+			expected: []bool{true, false},
+		},
+		{
+			name: "Missing keys: required -> MissingKeyError, optional skipped",
+			function: func() interface{} {
+				err := serverRules().Validate(map[string]string{})
+				return []interface{}{fieldsOf(err), sanity.CodeOf(err)}
+			},
+			expected: []interface{}{[]string{"port"}, sanity.CodeMissingKey},
+		},
+		{
+			name: "Nil map entry fails only presence rules",
+			function: func() interface{} {
+				return fieldsOf(serverRules().Validate(map[string]any{"port": nil, "mode": nil}))
+			},
+			expected: []string{"port"},
+		},
+		{
+			name: "Struct fields matched by json or Go name",
+			function: func() interface{} {
+				r := serverRules()
+				r.Field("Ratio").Max(1)
+				c := validServer()
+				c.Mode, c.Ratio = "x", 2
+				return fieldsOf(r.Validate(&c))
+			},
+			expected: []string{"mode", "Ratio"},
+		},
+		{
+			name: "Rules loaded from tag strings",
+			function: func() interface{} {
+				r := sanity.RulesFromTags(map[string]string{"workers": "max=64", "name": "nonblank"})
+				return fieldsOf(r.Validate(map[string]any{"name": " ", "workers": 100}))
+			},
+			expected: []string{"name", "workers"},
+		},
+		{
+			name: "Tag strings understand required",
+			function: func() interface{} {
+				r := sanity.RulesFromTags(map[string]string{"port": "required, nonzero"})
+				err := r.Validate(map[string]any{})
+				var mk sanity.MissingKeyError
+				return []interface{}{errors.As(err, &mk), fieldsOf(err), r.Validate(map[string]any{"port": 8080}) == nil}
+			},
+			expected: []interface{}{true, []string{"port"}, true},
+		},
+		{
+			name: "Programming errors returned immediately",
+			function: func() interface{} {
+				bad := sanity.NewRules()
+				bad.Field("mode").Tag("positive")
+				unknown := sanity.NewRules()
+				unknown.Field("nope").NonZero()
+				return []bool{
+					errors.Is(bad.Validate(map[string]any{"mode": "x"}), sanity.ErrBadTag),
+					errors.Is(unknown.Validate(validServer()), sanity.ErrBadTag),
+					errors.Is(serverRules().Validate(42), sanity.ErrNotStruct),
+				}
+			},
+			expected: []bool{true, true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
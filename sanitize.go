@@ -0,0 +1,52 @@
+package sanity
+
+// Defaulter is implemented by types that fill in their own defaults.
+type Defaulter interface {
+	SanityDefaults()
+}
+
+// Validator is implemented by types that validate themselves.
+type Validator interface {
+	SanityValidate() error
+}
+
+// Sanitize applies defaults and then validation to v, using whichever of
+// Defaulter and Validator v implements. It returns the validation error, if any.
+func Sanitize(v any) error {
+	if d, ok := v.(Defaulter); ok {
+		d.SanityDefaults()
+	}
+	if vv, ok := v.(Validator); ok {
+		return vv.SanityValidate()
+	}
+	return nil
+}
+
+// ApplyOptions applies functional options to target in order, collecting
+// every option error into one aggregate, and then runs Sanitize(target) so
+// the defaults and validation of a type using the options pattern live in its
+// Defaulter and Validator hooks:
+//
+//	func NewClient(opts ...func(*Client) error) (*Client, error) {
+//		c := &Client{}
+//		if err := sanity.ApplyOptions(c, opts); err != nil {
+//			return nil, err
+//		}
+//		return c, nil
+//	}
+//
+// Nil options are skipped. If any option fails, its errors are returned and
+// the hooks are not run, so a rejected option does not resurface as a
+// validation failure of the field it would have set.
+func ApplyOptions[T any](target *T, opts []func(*T) error) error {
+	g := NewGuard(WithMaxErrors(0))
+	for _, opt := range opts {
+		if opt != nil {
+			g.Check(opt(target))
+		}
+	}
+	if err := g.Err(); err != nil {
+		return err
+	}
+	return Sanitize(target)
+}
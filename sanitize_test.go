@@ -0,0 +1,127 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+type sanitizeReq struct {
+	Port int
+	Mode string
+}
+
+func (r *sanitizeReq) SanityDefaults() {
+	sanity.SetIfZero(&r.Port, 8080)
+}
+
+func (r *sanitizeReq) SanityValidate() error {
+	g := sanity.NewGuard(sanity.WithMaxErrors(0))
+	g.Check(sanity.InRangeNum("port", r.Port, 1, 65535))
+	g.Check(sanity.NonBlank("mode", r.Mode))
+	return g.Err()
+}
+
+func withPort(p int) func(*sanitizeReq) error {
+	return func(r *sanitizeReq) error {
+		if err := sanity.InRangeNum("port", p, 1, 65535); err != nil {
+			return err
+		}
+		r.Port = p
+		return nil
+	}
+}
+
+func withMode(m string) func(*sanitizeReq) error {
+	return func(r *sanitizeReq) error {
+		if err := sanity.OneOf("mode", m, "auto", "manual"); err != nil {
+			return err
+		}
+		r.Mode = m
+		return nil
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Defaults applied before validation",
+			function: func() interface{} {
+				r := sanitizeReq{Mode: "auto"}
+				err := sanity.Sanitize(&r)
+				return []interface{}{err == nil, r.Port}
+			},
+			expected: []interface{}{true, 8080},
+		},
+		{
+			name: "Validation error returned",
+			function: func() interface{} {
+				r := sanitizeReq{}
+				return errors.Is(sanity.Sanitize(&r), sanity.ErrNonEmpty)
+			},
+			expected: true,
+		},
+		{
+			name: "Plain value without hooks -> nil",
+			function: func() interface{} {
+				return sanity.Sanitize(&struct{ A int }{}) == nil
+			},
+			expected: true,
+		},
+		{
+			name: "ApplyOptions applies options, then defaults and validation",
+			function: func() interface{} {
+				var r sanitizeReq
+				err := sanity.ApplyOptions(&r, []func(*sanitizeReq) error{withMode("auto"), nil})
+				var bad sanitizeReq
+				verr := sanity.ApplyOptions(&bad, nil)
+				return []interface{}{err == nil, r, errors.Is(verr, sanity.ErrNonEmpty)}
+			},
+			expected: []interface{}{true, sanitizeReq{Port: 8080, Mode: "auto"}, true},
+		},
+		{
+			name: "ApplyOptions collects every option error and skips the hooks",
+			function: func() interface{} {
+				var r sanitizeReq
+				err := sanity.ApplyOptions(&r, []func(*sanitizeReq) error{withPort(0), withMode("x"), withPort(80)})
+				return []interface{}{fieldsOf(err), r.Port}
+			},
+			expected: []interface{}{[]string{"port", "mode"}, 80},
+		},
+		{
+			name: "NewErrorPayload lists field violations",
+			function: func() interface{} {
+				r := sanitizeReq{Port: -1}
+				p := sanity.NewErrorPayload(sanity.Sanitize(&r))
+				return []interface{}{p.Status, p.Message, len(p.Errors), p.Errors[0].Field, p.Errors[1]}
+			},
+			expected: []interface{}{422, "validation failed", 2, "port",
+				sanity.FieldViolation{Field: "mode", Code: "NON_EMPTY", Message: "must be non-empty"}},
+		},
+		{
+			name: "NewBindErrorPayload keeps decode message",
+			function: func() interface{} {
+				return sanity.NewBindErrorPayload(errors.New("unexpected EOF"))
+			},
+			expected: sanity.ErrorPayload{
+				Status:  400,
+				Message: "invalid request body",
+				Errors:  []sanity.FieldViolation{{Message: "unexpected EOF"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package sanity
 
 import (
+	"math"
 	"strings"
 )
 
@@ -69,6 +70,98 @@ func Clamp[T Numeric](p *T, min, max T) {
 	}
 }
 
+// ClampReport is Clamp that also reports whether *p was changed and its
+// previous value, so callers can warn when user input was normalized.
+func ClampReport[T Numeric](p *T, min, max T) (adjusted bool, old T) {
+	old = *p
+	Clamp(p, min, max)
+	return *p != old, old
+}
+
+// RoundToMultiple returns the multiple of step nearest to v, rounding halves
+// away from zero (e.g. RoundToMultiple(6000, 4096) == 4096). The sign of step
+// is ignored; a zero step returns v unchanged. For integers, when the nearest
+// multiple does not fit in T, v is rounded toward zero instead, so the result
+// never wraps around: RoundToMultiple(uint8(250), 100) == 200.
+func RoundToMultiple[T Numeric](v, step T) T {
+	if step < 0 {
+		step = -step
+		if step < 0 { // minimum signed value: its only multiples in T are 0 and step
+			if v <= step/2 {
+				return step
+			}
+			return 0
+		}
+	}
+	if step == 0 {
+		return v
+	}
+	if T(1)/T(2) != 0 { // floating point
+		return T(math.Round(float64(v)/float64(step)) * float64(step))
+	}
+	q := v / step
+	r := v - q*step
+	switch {
+	case r > 0 && r >= step-r:
+		if up := (q + 1) * step; up > v {
+			return up
+		}
+	case r < 0 && -r >= step+r:
+		if down := (q - 1) * step; down < v {
+			return down
+		}
+	}
+	return q * step
+}
+
+// SnapToStep rounds *p in place to the nearest multiple of step (see RoundToMultiple).
+func SnapToStep[T Numeric](p *T, step T) {
+	*p = RoundToMultiple(*p, step)
+}
+
+// Wrap maps v into the half-open range [min,max) by wrapping around instead of
+// clamping: Wrap(370, 0, 360) == 10, Wrap(-1, 0, n) == n-1. Bounds are swapped
+// if inverted; an empty range returns min.
+func Wrap[T Numeric](v, min, max T) T {
+	if min > max {
+		min, max = max, min
+	}
+	if min == max {
+		return min
+	}
+	if T(1)/T(2) != 0 { // floating point
+		span := max - min
+		r := math.Mod(float64(v-min), float64(span))
+		if r < 0 {
+			r += float64(span)
+		}
+		if w := min + T(r); w < max {
+			return w
+		}
+		return min
+	}
+	// Integer remainder in uint64: max-min overflows T for ranges wider than
+	// half its domain (Wrap[int8](50, -100, 100)), but the modular differences
+	// are exact there, and the result, in [min,max), converts back losslessly.
+	span := wrapU64(max) - wrapU64(min)
+	if v < min {
+		r := (wrapU64(min) - wrapU64(v)) % span
+		if r == 0 {
+			return min
+		}
+		return T(wrapU64(max) - r)
+	}
+	return T(wrapU64(min) + (wrapU64(v)-wrapU64(min))%span)
+}
+
+// wrapU64 returns the two's complement bits of integer x as a uint64.
+func wrapU64[T Numeric](x T) uint64 {
+	if T(0)-1 < 0 { // signed
+		return uint64(int64(x))
+	}
+	return uint64(x)
+}
+
 func DefaultIf[T comparable](v, def T) T {
 	var zero T
 	if v == zero {
@@ -77,6 +170,36 @@ func DefaultIf[T comparable](v, def T) T {
 	return v
 }
 
+// DefaultIfFunc is DefaultIf with a lazily computed default: def is only
+// called when v is zero.
+func DefaultIfFunc[T comparable](v T, def func() T) T {
+	var zero T
+	if v == zero {
+		return def()
+	}
+	return v
+}
+
+// SetIfZeroFunc is SetIfZero with a lazily computed default.
+func SetIfZeroFunc[T comparable](p *T, def func() T) {
+	var zero T
+	if *p == zero {
+		*p = def()
+	}
+}
+
+// Coalesce returns the first non-zero value, or the zero value if all are zero.
+// Order arguments by precedence, e.g. Coalesce(flag, env, cfg, 8080).
+func Coalesce[T comparable](vals ...T) T {
+	var zero T
+	for _, v := range vals {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
 func DefaultIfClamp[T Numeric](v, def, min, max T) T {
 	if min > max {
 		min, max = max, min
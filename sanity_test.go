@@ -2,7 +2,9 @@ package sanity_test
 
 import (
 	"github.com/sessaidi/sanity"
+	"math"
 	"testing"
+	"time"
 )
 
 func TestSetIfZero(t *testing.T) {
@@ -244,6 +246,137 @@ func TestClamp(t *testing.T) {
 	}
 }
 
+func TestClampReport(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fn       func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "in range -> not adjusted",
+			fn: func() interface{} {
+				v := 5
+				adjusted, old := sanity.ClampReport(&v, 1, 10)
+				return [3]interface{}{v, adjusted, old}
+			},
+			expected: [3]interface{}{5, false, 5},
+		},
+		{
+			name: "above max -> adjusted",
+			fn: func() interface{} {
+				v := 500
+				adjusted, old := sanity.ClampReport(&v, 1, 64)
+				return [3]interface{}{v, adjusted, old}
+			},
+			expected: [3]interface{}{64, true, 500},
+		},
+		{
+			name: "inverted bounds swapped",
+			fn: func() interface{} {
+				v := -1.5
+				adjusted, old := sanity.ClampReport(&v, 1.0, 0.0)
+				return [3]interface{}{v, adjusted, old}
+			},
+			expected: [3]interface{}{0.0, true, -1.5},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.fn()
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRoundToMultiple(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fn       func() interface{}
+		expected interface{}
+	}{
+		{name: "round down", fn: func() interface{} { return sanity.RoundToMultiple(6000, 4096) }, expected: 4096},
+		{name: "round up", fn: func() interface{} { return sanity.RoundToMultiple(7000, 4096) }, expected: 8192},
+		{name: "half away from zero", fn: func() interface{} { return sanity.RoundToMultiple(15, 10) }, expected: 20},
+		{name: "negative half away from zero", fn: func() interface{} { return sanity.RoundToMultiple(-15, 10) }, expected: -20},
+		{name: "negative round toward zero", fn: func() interface{} { return sanity.RoundToMultiple(-14, 10) }, expected: -10},
+		{name: "negative step", fn: func() interface{} { return sanity.RoundToMultiple(14, -10) }, expected: 10},
+		{name: "zero step", fn: func() interface{} { return sanity.RoundToMultiple(7, 0) }, expected: 7},
+		{name: "unsigned", fn: func() interface{} { return sanity.RoundToMultiple(uint8(130), 100) }, expected: uint8(100)},
+		{name: "float", fn: func() interface{} { return sanity.RoundToMultiple(0.74, 0.5) }, expected: 0.5},
+		{name: "unsigned near max rounds down", fn: func() interface{} { return sanity.RoundToMultiple(uint8(250), 100) }, expected: uint8(200)},
+		{name: "signed near max rounds down", fn: func() interface{} { return sanity.RoundToMultiple(int8(127), 50) }, expected: int8(100)},
+		{name: "signed near min rounds up", fn: func() interface{} { return sanity.RoundToMultiple(int8(-128), 50) }, expected: int8(-100)},
+		{name: "int64 max", fn: func() interface{} { return sanity.RoundToMultiple(int64(math.MaxInt64), 10) }, expected: int64(math.MaxInt64 - 7)},
+		{name: "min step half away from zero", fn: func() interface{} { return sanity.RoundToMultiple(int8(-64), math.MinInt8) }, expected: int8(math.MinInt8)},
+		{name: "min step toward zero", fn: func() interface{} { return sanity.RoundToMultiple(int8(-63), math.MinInt8) }, expected: int8(0)},
+		{name: "min step positive value", fn: func() interface{} { return sanity.RoundToMultiple(int8(127), math.MinInt8) }, expected: int8(0)},
+		{
+			name: "duration",
+			fn: func() interface{} {
+				return sanity.RoundToMultiple(250*time.Millisecond, 100*time.Millisecond)
+			},
+			expected: 300 * time.Millisecond,
+		},
+		{
+			name: "SnapToStep in place",
+			fn: func() interface{} {
+				v := 1000
+				sanity.SnapToStep(&v, 512)
+				return v
+			},
+			expected: 1024,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.fn()
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fn       func() interface{}
+		expected interface{}
+	}{
+		{name: "in range", fn: func() interface{} { return sanity.Wrap(5, 0, 10) }, expected: 5},
+		{name: "max wraps to min", fn: func() interface{} { return sanity.Wrap(10, 0, 10) }, expected: 0},
+		{name: "above", fn: func() interface{} { return sanity.Wrap(370, 0, 360) }, expected: 10},
+		{name: "below", fn: func() interface{} { return sanity.Wrap(-1, 0, 4) }, expected: 3},
+		{name: "far below", fn: func() interface{} { return sanity.Wrap(-9, 0, 4) }, expected: 3},
+		{name: "offset range", fn: func() interface{} { return sanity.Wrap(0, 1, 7) }, expected: 6},
+		{name: "inverted bounds", fn: func() interface{} { return sanity.Wrap(12, 10, 0) }, expected: 2},
+		{name: "empty range", fn: func() interface{} { return sanity.Wrap(5, 3, 3) }, expected: 3},
+		{name: "unsigned below", fn: func() interface{} { return sanity.Wrap(uint(2), 5, 10) }, expected: uint(7)},
+		{name: "int8 range wider than half the type", fn: func() interface{} { return sanity.Wrap[int8](50, -100, 100) }, expected: int8(50)},
+		{name: "int8 above full range", fn: func() interface{} { return sanity.Wrap[int8](127, -128, 127) }, expected: int8(-128)},
+		{name: "int8 below wide range", fn: func() interface{} { return sanity.Wrap[int8](-128, -100, 100) }, expected: int8(72)},
+		{name: "int16 above wide range", fn: func() interface{} { return sanity.Wrap[int16](32000, -30000, 30000) }, expected: int16(-28000)},
+		{name: "int16 below wide range", fn: func() interface{} { return sanity.Wrap[int16](-32768, -30000, 30000) }, expected: int16(27232)},
+		{name: "int64 full range", fn: func() interface{} { return sanity.Wrap[int64](math.MaxInt64, math.MinInt64, math.MaxInt64) }, expected: int64(math.MinInt64)},
+		{name: "uint8 full range", fn: func() interface{} { return sanity.Wrap[uint8](255, 0, 255) }, expected: uint8(0)},
+		{name: "float below", fn: func() interface{} { return sanity.Wrap(-30.0, 0, 360) }, expected: 330.0},
+		{name: "float tiny negative", fn: func() interface{} { return sanity.Wrap(-1e-18, 0, 360) }, expected: 0.0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.fn()
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
 func TestSetIfZeroThenClamp(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -362,6 +495,104 @@ func TestDefaultIf(t *testing.T) {
 	}
 }
 
+func TestDefaultIfFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fn       func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "zero -> default computed",
+			fn: func() interface{} {
+				return sanity.DefaultIfFunc(0, func() int { return 42 })
+			},
+			expected: 42,
+		},
+		{
+			name: "non-zero -> default not called",
+			fn: func() interface{} {
+				calls := 0
+				v := sanity.DefaultIfFunc("ok", func() string { calls++; return "def" })
+				return [2]interface{}{v, calls}
+			},
+			expected: [2]interface{}{"ok", 0},
+		},
+		{
+			name: "SetIfZeroFunc zero -> set",
+			fn: func() interface{} {
+				v := 0
+				sanity.SetIfZeroFunc(&v, func() int { return 8080 })
+				return v
+			},
+			expected: 8080,
+		},
+		{
+			name: "SetIfZeroFunc non-zero -> default not called",
+			fn: func() interface{} {
+				v, calls := 7, 0
+				sanity.SetIfZeroFunc(&v, func() int { calls++; return 8080 })
+				return [2]interface{}{v, calls}
+			},
+			expected: [2]interface{}{7, 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.fn()
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fn       func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "first non-zero wins",
+			fn: func() interface{} {
+				return sanity.Coalesce(0, 0, 9090, 8080)
+			},
+			expected: 9090,
+		},
+		{
+			name: "string cascade",
+			fn: func() interface{} {
+				return sanity.Coalesce("", "env", "cfg")
+			},
+			expected: "env",
+		},
+		{
+			name: "all zero -> zero",
+			fn: func() interface{} {
+				return sanity.Coalesce(0, 0)
+			},
+			expected: 0,
+		},
+		{
+			name: "no values -> zero",
+			fn: func() interface{} {
+				return sanity.Coalesce[string]()
+			},
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.fn()
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
 func TestDefaultIfClamp(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -0,0 +1,16 @@
+module github.com/sessaidi/sanity/sanitycfg
+
+go 1.24
+
+require (
+	github.com/sessaidi/sanity v0.4.0
+	github.com/stretchr/testify v1.11.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
+
+replace github.com/sessaidi/sanity => ..
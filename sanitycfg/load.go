@@ -0,0 +1,112 @@
+// Package sanitycfg loads config files into structs and runs them through
+// sanity: decode, environment overlay, default tags, then validation.
+//
+//	var cfg Config
+//	if err := sanitycfg.Load("config.yaml", &cfg, sanitycfg.WithEnvPrefix("APP_")); err != nil {
+//		log.Fatal(err)
+//	}
+package sanitycfg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sessaidi/sanity"
+)
+
+// ErrUnknownFormat is returned for a file extension without a decoder.
+var ErrUnknownFormat = errors.New("sanitycfg: unknown config format")
+
+// Decoder decodes a config document into v, like json.Unmarshal.
+type Decoder func(data []byte, v any) error
+
+// Option configures Load.
+type Option func(*loader)
+
+type loader struct {
+	decoders map[string]Decoder
+	lookup   func(string) (string, bool)
+	prefix   string
+	guard    []sanity.GuardOption
+}
+
+// WithDecoder registers d for files ending in ext (".toml"), replacing any
+// built-in decoder. JSON (".json") and YAML (".yaml", ".yml") are built in;
+// TOML needs a decoder such as toml.Unmarshal from github.com/BurntSushi/toml:
+//
+//	sanitycfg.Load("config.toml", &cfg, sanitycfg.WithDecoder(".toml", toml.Unmarshal))
+func WithDecoder(ext string, d Decoder) Option {
+	return func(l *loader) { l.decoders[strings.ToLower(ext)] = d }
+}
+
+// WithEnvPrefix prepends prefix to every `env` tag name before lookup.
+func WithEnvPrefix(prefix string) Option {
+	return func(l *loader) { l.prefix = prefix }
+}
+
+// WithLookupEnv replaces os.LookupEnv as the source of environment values.
+func WithLookupEnv(lookup func(string) (string, bool)) Option {
+	return func(l *loader) { l.lookup = lookup }
+}
+
+// WithGuardOptions configures the Guard that aggregates environment and
+// validation failures (unlimited by default).
+func WithGuardOptions(opts ...sanity.GuardOption) Option {
+	return func(l *loader) { l.guard = append(l.guard, opts...) }
+}
+
+// Load reads path, decodes it into v (a pointer to struct) by extension, then
+// applies, in order:
+//   - sanity.ApplyEnv: `env:"NAME"` tags overlay the file;
+//   - sanity.ApplyDefaults: `default:"..."` tags fill fields still zero;
+//   - sanity.ValidateStruct: `sanity:"..."` rules.
+//
+// Read, decode and tag errors are returned as is. Otherwise the result is the
+// aggregate of malformed environment values and validation failures, or nil.
+func Load(path string, v any, opts ...Option) error {
+	l := loader{
+		decoders: map[string]Decoder{".json": json.Unmarshal, ".yaml": yaml.Unmarshal, ".yml": yaml.Unmarshal},
+		lookup:   os.LookupEnv,
+	}
+	for _, opt := range opts {
+		opt(&l)
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	decode, ok := l.decoders[ext]
+	if !ok {
+		return fmt.Errorf("sanitycfg: %s: %w", path, ErrUnknownFormat)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := decode(data, v); err != nil {
+		return fmt.Errorf("sanitycfg: %s: %w", path, err)
+	}
+
+	g := sanity.NewGuard(append([]sanity.GuardOption{sanity.WithMaxErrors(0)}, l.guard...)...)
+	envErr := sanity.ApplyEnv(v, func(name string) (string, bool) { return l.lookup(l.prefix + name) })
+	if isProgrammingError(envErr) {
+		return envErr
+	}
+	g.Merge(envErr)
+	if err := sanity.ApplyDefaults(v); err != nil {
+		return err
+	}
+	valErr := sanity.ValidateStruct(v)
+	if isProgrammingError(valErr) {
+		return valErr
+	}
+	g.Merge(valErr)
+	return g.Err()
+}
+
+func isProgrammingError(err error) bool {
+	return errors.Is(err, sanity.ErrNotStruct) || errors.Is(err, sanity.ErrBadTag)
+}
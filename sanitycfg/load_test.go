@@ -0,0 +1,129 @@
+package sanitycfg_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+	"github.com/sessaidi/sanity/sanitycfg"
+)
+
+type config struct {
+	Port    int           `json:"port" yaml:"port" env:"PORT" default:"8080" sanity:"min=1,max=65535"`
+	Mode    string        `json:"mode" yaml:"mode" default:"auto" sanity:"oneof=auto|manual"`
+	Timeout time.Duration `json:"timeout" yaml:"timeout" env:"TIMEOUT" default:"5s" sanity:"max=1m"`
+}
+
+func write(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func env(m map[string]string) sanitycfg.Option {
+	return sanitycfg.WithLookupEnv(func(name string) (string, bool) {
+		v, ok := m[name]
+		return v, ok
+	})
+}
+
+func fieldsOf(err error) []string {
+	var out []string
+	for e := range sanity.Errors(err) {
+		var fe sanity.FieldError
+		if errors.As(e, &fe) {
+			out = append(out, fe.FieldName())
+		}
+	}
+	return out
+}
+
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "YAML with defaults",
+			function: func() interface{} {
+				var c config
+				err := sanitycfg.Load(write(t, "c.yaml", "mode: manual\n"), &c, env(nil))
+				return []interface{}{err == nil, c}
+			},
+			expected: []interface{}{true, config{Port: 8080, Mode: "manual", Timeout: 5 * time.Second}},
+		},
+		{
+			name: "JSON with prefixed env overlay",
+			function: func() interface{} {
+				var c config
+				err := sanitycfg.Load(write(t, "c.JSON", `{"port": 80}`), &c,
+					sanitycfg.WithEnvPrefix("APP_"), env(map[string]string{"APP_PORT": "9090", "TIMEOUT": "1s"}))
+				return []interface{}{err == nil, c}
+			},
+			expected: []interface{}{true, config{Port: 9090, Mode: "auto", Timeout: 5 * time.Second}},
+		},
+		{
+			name: "Env and validation failures aggregated",
+			function: func() interface{} {
+				var c config
+				err := sanitycfg.Load(write(t, "c.yml", "port: 0\nmode: x\n"), &c,
+					env(map[string]string{"TIMEOUT": "forever"}))
+				return []interface{}{
+					fieldsOf(err),
+					errors.Is(err, sanity.ErrInvalidFormat),
+					errors.Is(err, sanity.ErrNotInSet),
+				}
+			},
+			expected: []interface{}{[]string{"TIMEOUT", "mode"}, true, true},
+		},
+		{
+			name: "Guard options apply",
+			function: func() interface{} {
+				var c config
+				err := sanitycfg.Load(write(t, "c.yml", "port: 0\nmode: x\ntimeout: 1h\n"), &c,
+					env(nil), sanitycfg.WithGuardOptions(sanity.WithFieldPrefix("app")))
+				return fieldsOf(err)
+			},
+			expected: []string{"app.mode", "app.timeout"},
+		},
+		{
+			name: "Custom decoder for TOML",
+			function: func() interface{} {
+				var c config
+				err := sanitycfg.Load(write(t, "c.toml", `{"mode":"manual"}`), &c, env(nil),
+					sanitycfg.WithDecoder(".toml", json.Unmarshal))
+				return []interface{}{err == nil, c.Mode}
+			},
+			expected: []interface{}{true, "manual"},
+		},
+		{
+			name: "Unknown format, missing file and decode errors",
+			function: func() interface{} {
+				var c config
+				var se *json.SyntaxError
+				return []bool{
+					errors.Is(sanitycfg.Load(write(t, "c.toml", ""), &c), sanitycfg.ErrUnknownFormat),
+					errors.Is(sanitycfg.Load(filepath.Join(t.TempDir(), "none.json"), &c), os.ErrNotExist),
+					errors.As(sanitycfg.Load(write(t, "c.json", "{"), &c), &se),
+				}
+			},
+			expected: []bool{true, true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Package sanitycheck defines an analyzer that reports common misuse of the
+// sanity helpers:
+//
+//   - a mutating helper (SetIfZero, Clamp, ...) given the address of a copy,
+//     a range value variable or a value receiver, so the write is lost;
+//   - constant bounds with min > max (Clamp(&n, 10, 1));
+//   - SetIfZero or DefaultIf on a bool, where an explicit false is
+//     indistinguishable from unset and is always replaced;
+//   - DefaultIf on a pointer type, which compares the pointer with nil rather
+//     than the pointee with zero.
+//
+// Run it with go vet via cmd/sanitycheck:
+//
+//	go vet -vettool=$(which sanitycheck) ./...
+package sanitycheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const sanityPath = "github.com/sessaidi/sanity"
+
+// Analyzer reports misuse of the sanity package; see the package doc.
+var Analyzer = &analysis.Analyzer{
+	Name:     "sanitycheck",
+	Doc:      "report misuse of sanity helpers: writes to copies, min > max bounds, SetIfZero on bools, DefaultIf on pointers",
+	URL:      "https://pkg.go.dev/github.com/sessaidi/sanity/sanitycheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != sanityPath {
+			return true
+		}
+		sig := fn.Type().(*types.Signature)
+		if sig.Variadic() || sig.Params().Len() != len(call.Args) {
+			return true
+		}
+		checkLostWrite(pass, fn, call, stack)
+		checkBounds(pass, fn, call)
+		checkZeroValue(pass, fn, call)
+		return true
+	})
+	return nil, nil
+}
+
+// checkLostWrite reports a pointer argument p that addresses a copy.
+func checkLostWrite(pass *analysis.Pass, fn *types.Func, call *ast.CallExpr, stack []ast.Node) {
+	params := fn.Type().(*types.Signature).Params()
+	if params.Len() == 0 || params.At(0).Name() != "p" {
+		return
+	}
+	addr, ok := ast.Unparen(call.Args[0]).(*ast.UnaryExpr)
+	if !ok || addr.Op != token.AND {
+		return
+	}
+	root := copiedRoot(pass, addr.X)
+	if root == nil {
+		return
+	}
+	obj, ok := pass.TypesInfo.Uses[root].(*types.Var)
+	if !ok {
+		return
+	}
+	if what := copyKind(pass, obj, stack); what != "" {
+		pass.Reportf(addr.Pos(), "sanity.%s writes through &%s, but %s is a %s: the change is lost",
+			fn.Name(), types.ExprString(addr.X), root.Name, what)
+	}
+}
+
+// copiedRoot returns the variable whose storage x lives in, following field
+// selections and array indexing but not pointer indirections, or nil.
+func copiedRoot(pass *analysis.Pass, x ast.Expr) *ast.Ident {
+	for {
+		switch e := ast.Unparen(x).(type) {
+		case *ast.Ident:
+			return e
+		case *ast.SelectorExpr:
+			sel, ok := pass.TypesInfo.Selections[e]
+			if !ok || sel.Kind() != types.FieldVal || sel.Indirect() || isPointer(pass.TypesInfo.TypeOf(e.X)) {
+				return nil
+			}
+			x = e.X
+		case *ast.IndexExpr:
+			if _, ok := pass.TypesInfo.TypeOf(e.X).Underlying().(*types.Array); !ok {
+				return nil
+			}
+			x = e.X
+		default:
+			return nil
+		}
+	}
+}
+
+// copyKind reports whether obj is a range value variable or a value receiver
+// of an enclosing statement or method.
+func copyKind(pass *analysis.Pass, obj *types.Var, stack []ast.Node) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch s := stack[i].(type) {
+		case *ast.RangeStmt:
+			if id, ok := s.Value.(*ast.Ident); ok && s.Tok == token.DEFINE && pass.TypesInfo.Defs[id] == obj {
+				return "range value copy"
+			}
+		case *ast.FuncDecl:
+			if s.Recv != nil && len(s.Recv.List) == 1 && len(s.Recv.List[0].Names) == 1 {
+				if pass.TypesInfo.Defs[s.Recv.List[0].Names[0]] == obj && !isPointer(obj.Type()) {
+					return "value receiver"
+				}
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// checkBounds reports constant min and max arguments with min > max.
+func checkBounds(pass *analysis.Pass, fn *types.Func, call *ast.CallExpr) {
+	params := fn.Type().(*types.Signature).Params()
+	var min, max types.TypeAndValue
+	for i := 0; i < params.Len(); i++ {
+		switch params.At(i).Name() {
+		case "min":
+			min = pass.TypesInfo.Types[call.Args[i]]
+		case "max":
+			max = pass.TypesInfo.Types[call.Args[i]]
+		}
+	}
+	if !isNumeric(min.Value) || !isNumeric(max.Value) {
+		return
+	}
+	if constant.Compare(min.Value, token.GTR, max.Value) {
+		pass.Reportf(call.Pos(), "sanity.%s called with min %s > max %s", fn.Name(), formatConst(min), formatConst(max))
+	}
+}
+
+// formatConst prints time.Duration constants as durations ("1m0s").
+func formatConst(tv types.TypeAndValue) string {
+	if tv.Value.Kind() == constant.Int && tv.Type != nil && tv.Type.String() == "time.Duration" {
+		if n, ok := constant.Int64Val(tv.Value); ok {
+			return time.Duration(n).String()
+		}
+	}
+	return tv.Value.String()
+}
+
+func isNumeric(v constant.Value) bool {
+	if v == nil {
+		return false
+	}
+	switch v.Kind() {
+	case constant.Int, constant.Float:
+		return true
+	}
+	return false
+}
+
+// checkZeroValue reports zero-value defaulting on bools and DefaultIf on pointers.
+func checkZeroValue(pass *analysis.Pass, fn *types.Func, call *ast.CallExpr) {
+	var t types.Type
+	switch fn.Name() {
+	case "SetIfZero", "SetIfZeroFunc":
+		p, ok := pass.TypesInfo.TypeOf(call.Args[0]).Underlying().(*types.Pointer)
+		if !ok {
+			return
+		}
+		t = p.Elem()
+	case "DefaultIf", "DefaultIfFunc":
+		t = pass.TypesInfo.TypeOf(call.Args[0])
+	default:
+		return
+	}
+	if b, ok := t.Underlying().(*types.Basic); ok && b.Kind() == types.Bool {
+		pass.Reportf(call.Pos(), "sanity.%s on bool: an explicit false cannot be told from unset and is always replaced; use *bool with SetIfNil", fn.Name())
+	}
+	if isPointer(t) && (fn.Name() == "DefaultIf" || fn.Name() == "DefaultIfFunc") {
+		pass.Reportf(call.Pos(), "sanity.%s on pointer type %s compares the pointer with nil, not the pointee with zero; use FirstNonNil or dereference first",
+			fn.Name(), types.TypeString(t, types.RelativeTo(pass.Pkg)))
+	}
+}
+
+func isPointer(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	_, ok := t.Underlying().(*types.Pointer)
+	return ok
+}
@@ -0,0 +1,13 @@
+package sanitycheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/sessaidi/sanity/sanitycheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), sanitycheck.Analyzer, "a")
+}
@@ -0,0 +1,54 @@
+package a
+
+import (
+	"time"
+
+	"github.com/sessaidi/sanity"
+)
+
+type Server struct {
+	Port    int
+	Timeout time.Duration
+	Debug   bool
+	Name    *string
+	TLS     *TLS
+	Ports   [2]int
+}
+
+type TLS struct{ Port int }
+
+func rangeCopies(servers []Server, ptrs []*Server) {
+	for _, s := range servers {
+		sanity.SetIfZero(&s.Port, 80)          // want `sanity.SetIfZero writes through &s.Port, but s is a range value copy: the change is lost`
+		sanity.Clamp(&s.Ports[0], 1, 10)       // want `writes through &s.Ports\[0\], but s is a range value copy`
+		sanity.SetIfNil(&s.Name, nil)          // want `writes through &s.Name`
+		sanity.SetIfZero(&s.TLS.Port, 443)     // through a pointer: fine
+		sanity.SetIfZero(&servers[0].Port, 80) // slice element: fine
+	}
+	for i := range servers {
+		sanity.SetIfZero(&servers[i].Port, 80)
+	}
+	for _, p := range ptrs {
+		sanity.SetIfZero(&p.Port, 80)
+	}
+}
+
+func (s Server) ApplyDefaults() {
+	sanity.SetIfZero(&s.Port, 80) // want `sanity.SetIfZero writes through &s.Port, but s is a value receiver: the change is lost`
+}
+
+func (s *Server) Normalize() {
+	sanity.SetIfZero(&s.Port, 80)
+	sanity.Clamp(&s.Port, 65535, 1)                    // want `sanity.Clamp called with min 65535 > max 1`
+	sanity.Clamp(&s.Timeout, time.Minute, time.Second) // want `sanity.Clamp called with min 1m0s > max 1s`
+	_ = sanity.InRangeNum("port", s.Port, 10, 1)       // want `sanity.InRangeNum called with min 10 > max 1`
+	ratio := 0.9
+	sanity.Clamp(&ratio, 1.5, 0.5)                   // want `sanity.Clamp called with min 1.5 > max 0.5`
+	_ = sanity.InRangeNum("ratio", ratio, 1.0, 0.25) // want `sanity.InRangeNum called with min 1 > max 0.25`
+	_ = sanity.DefaultIfClamp(s.Port, 8080, 1, 65535)
+	sanity.SetIfZero(&s.Debug, true)                            // want `sanity.SetIfZero on bool`
+	sanity.SetIfZeroFunc(&s.Debug, func() bool { return true }) // want `sanity.SetIfZeroFunc on bool`
+	_ = sanity.DefaultIf(s.Debug, true)                         // want `sanity.DefaultIf on bool`
+	_ = sanity.DefaultIf(s.Name, nil)                           // want `sanity.DefaultIf on pointer type \*string compares the pointer with nil`
+	_ = sanity.DefaultIf(s.Port, 80)
+}
@@ -0,0 +1,14 @@
+// Package sanity is a stub with the signatures the analyzer inspects.
+package sanity
+
+type Numeric interface {
+	~int | ~int64 | ~uint | ~float64
+}
+
+func SetIfZero[T comparable](p *T, def T)                    {}
+func SetIfZeroFunc[T comparable](p *T, def func() T)         {}
+func SetIfNil[T any](p **T, def *T)                          {}
+func Clamp[T Numeric](p *T, min, max T)                      {}
+func InRangeNum[T Numeric](name string, v, min, max T) error { return nil }
+func DefaultIf[T comparable](v, def T) T                     { return v }
+func DefaultIfClamp[T Numeric](v, def, min, max T) T         { return v }
@@ -0,0 +1,30 @@
+// Package sanityecho adapts sanity to echo handlers.
+//
+// It depends only on the method set of echo.Context, so importing it does not
+// pull echo into modules that do not already use it.
+package sanityecho
+
+import "github.com/sessaidi/sanity"
+
+// Context is the subset of echo.Context used by this package.
+type Context interface {
+	Bind(i any) error
+	JSON(code int, i any) error
+}
+
+// BindValidated binds the request into obj, then applies sanity.Sanitize.
+// On failure it writes a sanity.ErrorPayload and returns the original error;
+// echo's error handler leaves the already committed response untouched.
+func BindValidated(c Context, obj any) error {
+	if err := c.Bind(obj); err != nil {
+		p := sanity.NewBindErrorPayload(err)
+		_ = c.JSON(p.Status, p)
+		return err
+	}
+	if err := sanity.Sanitize(obj); err != nil {
+		p := sanity.NewErrorPayload(err)
+		_ = c.JSON(p.Status, p)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package sanityecho_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+	"github.com/sessaidi/sanity/sanityecho"
+)
+
+type fakeContext struct {
+	bindErr error
+	port    int
+	code    int
+	body    any
+}
+
+func (c *fakeContext) Bind(i any) error {
+	if c.bindErr != nil {
+		return c.bindErr
+	}
+	i.(*request).Port = c.port
+	return nil
+}
+
+func (c *fakeContext) JSON(code int, i any) error {
+	c.code, c.body = code, i
+	return nil
+}
+
+type request struct{ Port int }
+
+func (r *request) SanityValidate() error {
+	return sanity.InRangeNum("port", r.Port, 1, 65535)
+}
+
+func TestBindValidated(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Valid request -> nil and nothing written",
+			function: func() interface{} {
+				c := &fakeContext{port: 443}
+				var req request
+				err := sanityecho.BindValidated(c, &req)
+				return []interface{}{err == nil, c.code, req.Port}
+			},
+			expected: []interface{}{true, 0, 443},
+		},
+		{
+			name: "Bind failure -> 400",
+			function: func() interface{} {
+				c := &fakeContext{bindErr: errors.New("bad json")}
+				err := sanityecho.BindValidated(c, &request{})
+				return []interface{}{err != nil, c.code}
+			},
+			expected: []interface{}{true, 400},
+		},
+		{
+			name: "Validation failure -> 422 with payload",
+			function: func() interface{} {
+				c := &fakeContext{port: 70000}
+				err := sanityecho.BindValidated(c, &request{})
+				p, _ := c.body.(sanity.ErrorPayload)
+				return []interface{}{errors.Is(err, sanity.ErrOutOfRange), c.code, len(p.Errors)}
+			},
+			expected: []interface{}{true, 422, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
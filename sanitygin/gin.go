@@ -0,0 +1,29 @@
+// Package sanitygin adapts sanity to gin handlers.
+//
+// It depends only on the method set of *gin.Context, so importing it does not
+// pull gin into modules that do not already use it.
+package sanitygin
+
+import "github.com/sessaidi/sanity"
+
+// Context is the subset of *gin.Context used by this package.
+type Context interface {
+	ShouldBind(obj any) error
+	AbortWithStatusJSON(code int, jsonObj any)
+}
+
+// ShouldBindValidated binds the request into obj, then applies sanity.Sanitize.
+// On failure it aborts with a sanity.ErrorPayload and returns the error.
+func ShouldBindValidated(c Context, obj any) error {
+	if err := c.ShouldBind(obj); err != nil {
+		p := sanity.NewBindErrorPayload(err)
+		c.AbortWithStatusJSON(p.Status, p)
+		return err
+	}
+	if err := sanity.Sanitize(obj); err != nil {
+		p := sanity.NewErrorPayload(err)
+		c.AbortWithStatusJSON(p.Status, p)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package sanitytest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sessaidi/sanity"
+)
+
+// AssertValid reports a failure listing every member of err unless err is nil.
+// It returns whether the assertion held.
+func AssertValid(t testing.TB, err error) bool {
+	t.Helper()
+	if err == nil {
+		return true
+	}
+	t.Errorf("sanitytest: want no errors, got %d:%s", count(err), describe(err))
+	return false
+}
+
+// AssertFieldError reports a failure unless some member of err has field path
+// field and matches target via errors.Is; a nil target matches any category.
+// It returns whether the assertion held.
+//
+//	sanitytest.AssertFieldError(t, err, "port", sanity.ErrOutOfRange)
+func AssertFieldError(t testing.TB, err error, field string, target error) bool {
+	t.Helper()
+	for e := range sanity.Errors(err) {
+		var fe sanity.FieldError
+		if errors.As(e, &fe) && fe.FieldName() == field && (target == nil || errors.Is(e, target)) {
+			return true
+		}
+	}
+	want := fmt.Sprintf("%q", field)
+	if target != nil {
+		want += " matching " + target.Error()
+	}
+	if err == nil {
+		t.Errorf("sanitytest: want an error for field %s, got nil", want)
+	} else {
+		t.Errorf("sanitytest: want an error for field %s, got:%s", want, describe(err))
+	}
+	return false
+}
+
+// AssertErrCount reports a failure unless err has exactly n members (nil has
+// none, a single error one). The clamp sentinel of a capped aggregate is not
+// counted. It returns whether the assertion held.
+func AssertErrCount(t testing.TB, err error, n int) bool {
+	t.Helper()
+	if got := count(err); got != n {
+		t.Errorf("sanitytest: want %d errors, got %d:%s", n, got, describe(err))
+		return false
+	}
+	return true
+}
+
+func count(err error) int {
+	n := 0
+	for e := range sanity.Errors(err) {
+		if !errors.Is(e, sanity.ErrClamped) {
+			n++
+		}
+	}
+	return n
+}
+
+// describe lists the members of err one per line as "field: message [CODE]".
+func describe(err error) string {
+	if err == nil {
+		return " none"
+	}
+	var b strings.Builder
+	for _, v := range sanity.NewErrorPayload(err).Errors {
+		b.WriteString("\n\t")
+		if v.Field != "" {
+			b.WriteString(v.Field + ": ")
+		}
+		b.WriteString(v.Message)
+		if v.Code != "" {
+			b.WriteString(" [" + v.Code + "]")
+		}
+	}
+	return b.String()
+}
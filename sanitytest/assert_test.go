@@ -0,0 +1,88 @@
+package sanitytest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+	"github.com/sessaidi/sanity/sanitytest"
+)
+
+func failures() error {
+	g := sanity.NewGuard(sanity.WithMaxErrors(0))
+	g.Add(sanity.InRangeNum("port", 0, 1, 65535))
+	g.Add(sanity.OneOf("mode", "x", "auto", "manual"))
+	g.Add(errors.New("backend unavailable"))
+	return g.Err()
+}
+
+func TestAssertions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Passing assertions record nothing",
+			function: func() interface{} {
+				rec := &recorder{TB: t}
+				ok := sanitytest.AssertValid(rec, nil) &&
+					sanitytest.AssertFieldError(rec, failures(), "port", sanity.ErrOutOfRange) &&
+					sanitytest.AssertFieldError(rec, failures(), "mode", nil) &&
+					sanitytest.AssertErrCount(rec, failures(), 3) &&
+					sanitytest.AssertErrCount(rec, nil, 0)
+				return []interface{}{ok, len(rec.failures)}
+			},
+			expected: []interface{}{true, 0},
+		},
+		{
+			name: "AssertValid lists every member",
+			function: func() interface{} {
+				rec := &recorder{TB: t}
+				ok := sanitytest.AssertValid(rec, failures())
+				msg := rec.failures[0]
+				return []interface{}{
+					ok,
+					msg == "sanitytest: want no errors, got 3:"+
+						"\n\tport: must be in [1,65535], got 0 [OUT_OF_RANGE]"+
+						"\n\tmode: must be one of [auto, manual] [NOT_IN_SET]"+
+						"\n\tbackend unavailable" || sanity.RedactBuild,
+				}
+			},
+			expected: []interface{}{false, true},
+		},
+		{
+			name: "AssertFieldError on wrong category or missing field",
+			function: func() interface{} {
+				rec := &recorder{TB: t}
+				wrong := sanitytest.AssertFieldError(rec, failures(), "port", sanity.ErrNonZero)
+				missing := sanitytest.AssertFieldError(rec, nil, "port", nil)
+				return []interface{}{wrong, missing, len(rec.failures), rec.failures[1]}
+			},
+			expected: []interface{}{false, false, 2, `sanitytest: want an error for field "port", got nil`},
+		},
+		{
+			name: "AssertErrCount ignores the clamp sentinel",
+			function: func() interface{} {
+				rec := &recorder{TB: t}
+				g := sanity.NewGuard(sanity.WithMaxErrors(2))
+				g.Add(sanity.NonZero("a", 0))
+				g.Add(sanity.NonZero("b", 0))
+				g.Add(sanity.NonZero("c", 0))
+				ok := sanitytest.AssertErrCount(rec, g.Err(), 2)
+				bad := sanitytest.AssertErrCount(rec, sanity.NonZero("a", 0), 2)
+				return []interface{}{ok, bad, len(rec.failures)}
+			},
+			expected: []interface{}{true, false, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package sanitytest
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HugeLen is the length of the oversized string Boundary includes for strings.
+const HugeLen = 1 << 16
+
+// Boundary returns edge-case inputs of type T for rule, written in the
+// sanity struct-tag grammar ("min=1,max=65535", "minlen=3,nonblank",
+// "oneof=auto|manual"), so property and fuzz tests exercise exactly the
+// values validators care about:
+//
+//   - numbers: zero, min-1, min, min+1, max-1, max, max+1 and the type's
+//     extremes; floats step to the adjacent representable value and add
+//     NaN and ±Inf; time.Duration steps by 1ns;
+//   - strings: "", blank strings, invalid UTF-8, lengths minlen-1, minlen
+//     and minlen+1, a HugeLen string, and near misses of oneof values
+//     (different case, trailing space);
+//   - oneof numbers: each value and its neighbours.
+//
+// Values a type cannot represent (min-1 at its minimum) are skipped and
+// duplicates removed; order is deterministic. Boundary panics on a malformed
+// rule or an unsupported T, both programming errors in the test.
+//
+//	for _, port := range sanitytest.Boundary[int]("min=1,max=65535") {
+//		f.Add(port)
+//	}
+func Boundary[T any](rule string) []T {
+	t := reflect.TypeFor[T]()
+	r, err := parseRule(rule, t)
+	if err != nil {
+		panic(fmt.Sprintf("sanitytest: Boundary(%q) for %s: %v", rule, t, err))
+	}
+	b := boundaries{t: t, seen: make(map[string]bool)}
+	switch k := t.Kind(); {
+	case t == durationType || isInt(k):
+		b.ints(r)
+	case isUint(k):
+		b.uints(r)
+	case k == reflect.Float32 || k == reflect.Float64:
+		b.floats(r)
+	case k == reflect.String:
+		b.strings(r)
+	default:
+		panic(fmt.Sprintf("sanitytest: Boundary for unsupported %s", t))
+	}
+	out := make([]T, len(b.vals))
+	for i, v := range b.vals {
+		out[i] = v.Interface().(T)
+	}
+	return out
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+func isInt(k reflect.Kind) bool  { return k >= reflect.Int && k <= reflect.Int64 }
+func isUint(k reflect.Kind) bool { return k >= reflect.Uint && k <= reflect.Uintptr }
+
+// rule is the subset of the tag grammar that shapes boundaries.
+type rule struct {
+	min, max       string
+	hasMin, hasMax bool
+	minLen         int // < 0 => unset
+	oneOf          []string
+}
+
+func parseRule(s string, t reflect.Type) (rule, error) {
+	r := rule{minLen: -1}
+	if strings.TrimSpace(s) == "" {
+		return r, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		key, val, hasVal := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "notnil", "nonzero", "nonempty", "nonblank":
+			continue
+		case "minlen", "min", "max", "oneof":
+			if !hasVal {
+				return r, fmt.Errorf("rule %q needs a value", key)
+			}
+		default:
+			return r, fmt.Errorf("unknown rule %q", key)
+		}
+		var err error
+		switch key {
+		case "minlen":
+			r.minLen, err = strconv.Atoi(val)
+		case "min":
+			r.hasMin, r.min = true, val
+		case "max":
+			r.hasMax, r.max = true, val
+		case "oneof":
+			r.oneOf = strings.Split(val, "|")
+		}
+		if err != nil {
+			return r, fmt.Errorf("rule %q: %v", part, err)
+		}
+	}
+	if (r.hasMin || r.hasMax) && t.Kind() == reflect.String {
+		return r, fmt.Errorf("min/max on %s", t)
+	}
+	return r, nil
+}
+
+// boundaries accumulates distinct values of t in insertion order.
+type boundaries struct {
+	t    reflect.Type
+	vals []reflect.Value
+	seen map[string]bool
+}
+
+func (b *boundaries) add(v reflect.Value) {
+	key := fmt.Sprintf("%#v", v.Interface())
+	if !b.seen[key] {
+		b.seen[key] = true
+		b.vals = append(b.vals, v)
+	}
+}
+
+func (b *boundaries) int(n int64) {
+	v := reflect.New(b.t).Elem()
+	if !v.OverflowInt(n) {
+		v.SetInt(n)
+		b.add(v)
+	}
+}
+
+func (b *boundaries) parseInt(s string) int64 {
+	if b.t == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			panic(fmt.Sprintf("sanitytest: Boundary bound %q: %v", s, err))
+		}
+		return int64(d)
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("sanitytest: Boundary bound %q: %v", s, err))
+	}
+	return n
+}
+
+// intsAround adds n-1, n and n+1, skipping steps that would wrap.
+func (b *boundaries) intsAround(n int64) {
+	if n > math.MinInt64 {
+		b.int(n - 1)
+	}
+	b.int(n)
+	if n < math.MaxInt64 {
+		b.int(n + 1)
+	}
+}
+
+func (b *boundaries) ints(r rule) {
+	b.int(0)
+	if r.hasMin {
+		b.intsAround(b.parseInt(r.min))
+	}
+	if r.hasMax {
+		b.intsAround(b.parseInt(r.max))
+	}
+	for _, s := range r.oneOf {
+		b.intsAround(b.parseInt(s))
+	}
+	bits := b.t.Bits()
+	b.int(int64(-1) << (bits - 1))
+	b.int(int64(1)<<(bits-1) - 1)
+}
+
+func (b *boundaries) uint(n uint64) {
+	v := reflect.New(b.t).Elem()
+	if !v.OverflowUint(n) {
+		v.SetUint(n)
+		b.add(v)
+	}
+}
+
+func (b *boundaries) uintsAround(s string) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("sanitytest: Boundary bound %q: %v", s, err))
+	}
+	if n > 0 {
+		b.uint(n - 1)
+	}
+	b.uint(n)
+	if n < math.MaxUint64 {
+		b.uint(n + 1)
+	}
+}
+
+func (b *boundaries) uints(r rule) {
+	b.uint(0)
+	if r.hasMin {
+		b.uintsAround(r.min)
+	}
+	if r.hasMax {
+		b.uintsAround(r.max)
+	}
+	for _, s := range r.oneOf {
+		b.uintsAround(s)
+	}
+	b.uint(math.MaxUint64 >> (64 - b.t.Bits()))
+}
+
+func (b *boundaries) float(f float64) {
+	v := reflect.New(b.t).Elem()
+	if math.IsNaN(f) || math.IsInf(f, 0) || !v.OverflowFloat(f) {
+		v.SetFloat(f)
+		b.add(v)
+	}
+}
+
+// floatsAround adds f and its adjacent representable values in t.
+func (b *boundaries) floatsAround(s string) {
+	f, err := strconv.ParseFloat(s, b.t.Bits())
+	if err != nil {
+		panic(fmt.Sprintf("sanitytest: Boundary bound %q: %v", s, err))
+	}
+	if b.t.Kind() == reflect.Float32 {
+		f32 := float32(f)
+		b.float(float64(math.Nextafter32(f32, float32(math.Inf(-1)))))
+		b.float(float64(f32))
+		b.float(float64(math.Nextafter32(f32, float32(math.Inf(1)))))
+		return
+	}
+	b.float(math.Nextafter(f, math.Inf(-1)))
+	b.float(f)
+	b.float(math.Nextafter(f, math.Inf(1)))
+}
+
+func (b *boundaries) floats(r rule) {
+	b.float(0)
+	if r.hasMin {
+		b.floatsAround(r.min)
+	}
+	if r.hasMax {
+		b.floatsAround(r.max)
+	}
+	for _, s := range r.oneOf {
+		b.floatsAround(s)
+	}
+	b.float(math.NaN())
+	b.float(math.Inf(-1))
+	b.float(math.Inf(1))
+}
+
+func (b *boundaries) string(s string) {
+	v := reflect.New(b.t).Elem()
+	v.SetString(s)
+	b.add(v)
+}
+
+func (b *boundaries) strings(r rule) {
+	b.string("")
+	b.string(" ")
+	b.string("\t\n")
+	b.string("\xff")
+	if r.minLen >= 0 {
+		if r.minLen > 0 {
+			b.string(strings.Repeat("a", r.minLen-1))
+		}
+		b.string(strings.Repeat("a", r.minLen))
+		b.string(strings.Repeat("a", r.minLen+1))
+	}
+	for _, s := range r.oneOf {
+		b.string(s)
+		b.string(strings.ToUpper(s))
+		b.string(s + " ")
+	}
+	b.string(strings.Repeat("a", HugeLen))
+}
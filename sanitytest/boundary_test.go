@@ -0,0 +1,108 @@
+package sanitytest_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+	"github.com/sessaidi/sanity/sanitytest"
+)
+
+func TestBoundary(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Integers around both bounds plus extremes",
+			function: func() interface{} {
+				return sanitytest.Boundary[int8]("nonzero,min=1,max=100")
+			},
+			expected: []int8{0, 1, 2, 99, 100, 101, math.MinInt8, math.MaxInt8},
+		},
+		{
+			name: "Unrepresentable neighbours skipped",
+			function: func() interface{} {
+				return sanitytest.Boundary[uint8]("min=0,max=255")
+			},
+			expected: []uint8{0, 1, 254, 255},
+		},
+		{
+			name: "Durations step by a nanosecond",
+			function: func() interface{} {
+				return sanitytest.Boundary[time.Duration]("max=1s")[:4]
+			},
+			expected: []time.Duration{0, time.Second - 1, time.Second, time.Second + 1},
+		},
+		{
+			name: "Floats step to adjacent values and add NaN and infinities",
+			function: func() interface{} {
+				got := sanitytest.Boundary[float64]("min=0,max=1")
+				return []interface{}{
+					len(got),
+					got[1] == -math.SmallestNonzeroFloat64,
+					got[3] == 1-0x1p-53,
+					got[5] == math.Nextafter(1, 2),
+					math.IsNaN(got[6]),
+					got[7:],
+				}
+			},
+			expected: []interface{}{9, true, true, true, true, []float64{math.Inf(-1), math.Inf(1)}},
+		},
+		{
+			name: "Strings: blanks, lengths and near misses",
+			function: func() interface{} {
+				got := sanitytest.Boundary[string]("minlen=2,oneof=ab|cd")
+				return []interface{}{got[:len(got)-1], len(got[len(got)-1])}
+			},
+			expected: []interface{}{
+				[]string{"", " ", "\t\n", "\xff", "a", "aa", "aaa", "ab", "AB", "ab ", "cd", "CD", "cd "},
+				sanitytest.HugeLen,
+			},
+		},
+		{
+			name: "Boundaries straddle what the validator accepts",
+			function: func() interface{} {
+				var accepted, rejected int
+				for _, v := range sanitytest.Boundary[int]("min=1,max=65535") {
+					if sanity.InRangeNum("port", v, 1, 65535) == nil {
+						accepted++
+					} else {
+						rejected++
+					}
+				}
+				return []int{accepted, rejected}
+			},
+			expected: []int{4, 4},
+		},
+		{
+			name: "Programming errors panic",
+			function: func() interface{} {
+				return []bool{
+					panics(func() { sanitytest.Boundary[int]("positive") }),
+					panics(func() { sanitytest.Boundary[int]("min=x") }),
+					panics(func() { sanitytest.Boundary[string]("max=3") }),
+					panics(func() { sanitytest.Boundary[[]int]("") }),
+				}
+			},
+			expected: []bool{true, true, true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func panics(fn func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	fn()
+	return false
+}
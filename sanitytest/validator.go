@@ -0,0 +1,105 @@
+// Package sanitytest provides helpers for testing code built on sanity,
+// including custom validators held to the same standard as the built-ins.
+package sanitytest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/sessaidi/sanity"
+)
+
+// ValidatorSpec describes a validator and how to generate inputs for it.
+type ValidatorSpec[T any] struct {
+	Field    string                       // field name passed to Validate; default "field"
+	Validate func(name string, v T) error // validator under test
+	Valid    func(r *rand.Rand) T         // generator of inputs that must pass
+	Invalid  func(r *rand.Rand) T         // generator of inputs that must fail
+	Sentinel error                        // category expected via errors.Is on failure; nil skips
+	Runs     int                          // inputs per generator; default 100
+	Seed     uint64                       // PRNG seed; runs are deterministic
+}
+
+// CheckValidator asserts, for generated inputs, that the validator:
+//   - returns nil for valid inputs,
+//   - returns an error matching Sentinel for invalid inputs,
+//   - reports Field via sanity.FieldError,
+//   - omits "got ..." details when redaction is on (build tag or SetRedaction),
+//   - never panics.
+//
+// Each property reports at most its first violation.
+func CheckValidator[T any](t testing.TB, spec ValidatorSpec[T]) {
+	t.Helper()
+	if spec.Validate == nil {
+		t.Errorf("sanitytest: ValidatorSpec.Validate is nil")
+		return
+	}
+	field := spec.Field
+	if field == "" {
+		field = "field"
+	}
+	runs := spec.Runs
+	if runs <= 0 {
+		runs = 100
+	}
+	r := rand.New(rand.NewPCG(spec.Seed, spec.Seed^0x9e3779b97f4a7c15))
+
+	if spec.Valid != nil {
+		for i := 0; i < runs; i++ {
+			v := spec.Valid(r)
+			err, panicked := call(spec.Validate, field, v)
+			if panicked != nil {
+				t.Errorf("sanitytest: panic on valid input %#v: %v", v, panicked)
+				break
+			}
+			if err != nil {
+				t.Errorf("sanitytest: valid input %#v rejected: %v", v, err)
+				break
+			}
+		}
+	}
+
+	if spec.Invalid != nil {
+		for i := 0; i < runs; i++ {
+			v := spec.Invalid(r)
+			err, panicked := call(spec.Validate, field, v)
+			if panicked != nil {
+				t.Errorf("sanitytest: panic on invalid input %#v: %v", v, panicked)
+				break
+			}
+			if msg := checkInvalid(err, field, spec.Sentinel); msg != "" {
+				t.Errorf("sanitytest: invalid input %#v: %s", v, msg)
+				break
+			}
+		}
+	}
+}
+
+func checkInvalid(err error, field string, sentinel error) string {
+	if err == nil {
+		return "accepted"
+	}
+	if sentinel != nil && !errors.Is(err, sentinel) {
+		return fmt.Sprintf("error %q does not match sentinel %v", err, sentinel)
+	}
+	var fe sanity.FieldError
+	if !errors.As(err, &fe) {
+		return fmt.Sprintf("error %q does not implement sanity.FieldError", err)
+	}
+	if fe.FieldName() != field {
+		return fmt.Sprintf("FieldName() = %q, want %q", fe.FieldName(), field)
+	}
+	redacted := sanity.RedactBuild || sanity.CurrentRedaction() == sanity.RedactValues
+	if redacted && strings.Contains(err.Error(), "got ") {
+		return fmt.Sprintf("redacted build leaks value: %q", err)
+	}
+	return ""
+}
+
+func call[T any](fn func(string, T) error, name string, v T) (err error, panicked any) {
+	defer func() { panicked = recover() }()
+	return fn(name, v), nil
+}
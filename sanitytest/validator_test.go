@@ -0,0 +1,111 @@
+package sanitytest_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+	"github.com/sessaidi/sanity/sanitytest"
+)
+
+// recorder captures failures instead of failing the enclosing test.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func portSpec() sanitytest.ValidatorSpec[int] {
+	return sanitytest.ValidatorSpec[int]{
+		Field: "port",
+		Validate: func(name string, v int) error {
+			return sanity.InRangeNum(name, v, 1, 65535)
+		},
+		Valid:    func(r *rand.Rand) int { return 1 + r.IntN(65535) },
+		Invalid:  func(r *rand.Rand) int { return -r.IntN(1000) },
+		Sentinel: sanity.ErrOutOfRange,
+	}
+}
+
+func TestCheckValidator(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Built-in validator passes",
+			function: func() interface{} {
+				rec := &recorder{TB: t}
+				sanitytest.CheckValidator(rec, portSpec())
+				return len(rec.failures)
+			},
+			expected: 0,
+		},
+		{
+			name: "Rejecting valid input is reported",
+			function: func() interface{} {
+				rec := &recorder{TB: t}
+				spec := portSpec()
+				spec.Validate = func(name string, v int) error { return sanity.InRangeNum(name, v, 1, 10) }
+				sanitytest.CheckValidator(rec, spec)
+				return len(rec.failures)
+			},
+			expected: 1,
+		},
+		{
+			name: "Wrong sentinel is reported",
+			function: func() interface{} {
+				rec := &recorder{TB: t}
+				spec := portSpec()
+				spec.Sentinel = sanity.ErrNonZero
+				sanitytest.CheckValidator(rec, spec)
+				return len(rec.failures)
+			},
+			expected: 1,
+		},
+		{
+			name: "Bare errors without field are reported",
+			function: func() interface{} {
+				rec := &recorder{TB: t}
+				spec := portSpec()
+				spec.Sentinel = nil
+				spec.Validate = func(name string, v int) error {
+					if v < 1 {
+						return errors.New("bad port")
+					}
+					return nil
+				}
+				sanitytest.CheckValidator(rec, spec)
+				return len(rec.failures)
+			},
+			expected: 1,
+		},
+		{
+			name: "Panics are caught and reported",
+			function: func() interface{} {
+				rec := &recorder{TB: t}
+				spec := portSpec()
+				spec.Validate = func(string, int) error { panic("boom") }
+				sanitytest.CheckValidator(rec, spec)
+				return len(rec.failures)
+			},
+			expected: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSign is the category sentinel of SignError.
+var ErrSign = errors.New("sanity:sign")
+
+// Sign requirements reported in SignError.Want.
+const (
+	SignPositive    = "positive"     // > 0
+	SignNonNegative = "non-negative" // >= 0
+	SignNegative    = "negative"     // < 0
+	SignNonPositive = "non-positive" // <= 0
+)
+
+// SignError indicates a number with the wrong sign. NaN fails every check.
+type SignError[T Numeric] struct {
+	Field string
+	Want  string // SignPositive, SignNonNegative, SignNegative, SignNonPositive
+	Got   T
+}
+
+func (e SignError[T]) Unwrap() error     { return ErrSign }
+func (e SignError[T]) FieldName() string { return e.Field }
+func (e SignError[T]) Code() string      { return CodeSign }
+func (e SignError[T]) Error() string     { return e.FieldName() + ": " + e.message(redacting()) }
+func (e SignError[T]) gotValue() any     { return e.Got }
+func (e SignError[T]) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e SignError[T]) message(redact bool) string {
+	if redact {
+		return "must be " + e.Want
+	}
+	return fmt.Sprintf("must be %s, got %v", e.Want, e.Got)
+}
+
+// The checks are written as !(cond) so that NaN fails them.
+
+func Positive[T Numeric](name string, v T) error {
+	if !(v > 0) {
+		return SignError[T]{Field: name, Want: SignPositive, Got: v}
+	}
+	return nil
+}
+
+func NonNegative[T Numeric](name string, v T) error {
+	if !(v >= 0) {
+		return SignError[T]{Field: name, Want: SignNonNegative, Got: v}
+	}
+	return nil
+}
+
+func Negative[T Numeric](name string, v T) error {
+	if !(v < 0) {
+		return SignError[T]{Field: name, Want: SignNegative, Got: v}
+	}
+	return nil
+}
+
+func NonPositive[T Numeric](name string, v T) error {
+	if !(v <= 0) {
+		return SignError[T]{Field: name, Want: SignNonPositive, Got: v}
+	}
+	return nil
+}
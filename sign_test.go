@@ -0,0 +1,78 @@
+package sanity_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestSignValidators(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Passing values",
+			function: func() interface{} {
+				return []bool{
+					sanity.Positive("n", 1) == nil,
+					sanity.NonNegative("n", 0) == nil,
+					sanity.Negative("n", -0.5) == nil,
+					sanity.NonPositive("n", int8(0)) == nil,
+					sanity.NonNegative("n", uint(0)) == nil,
+				}
+			},
+			expected: []bool{true, true, true, true, true},
+		},
+		{
+			name: "Failures match ErrSign and carry the requirement",
+			function: func() interface{} {
+				err := sanity.Positive("workers", 0)
+				var se sanity.SignError[int]
+				return []interface{}{
+					errors.Is(err, sanity.ErrSign), errors.As(err, &se), se.Want, se.Got, sanity.CodeOf(err),
+					errors.Is(sanity.NonNegative("n", -1), sanity.ErrSign),
+					errors.Is(sanity.Negative("n", uint8(3)), sanity.ErrSign),
+					errors.Is(sanity.NonPositive("n", 2.5), sanity.ErrSign),
+				}
+			},
+			expected: []interface{}{true, true, sanity.SignPositive, 0, sanity.CodeSign, true, true, true},
+		},
+		{
+			name: "NaN fails every check",
+			function: func() interface{} {
+				nan := math.NaN()
+				return []bool{
+					sanity.Positive("f", nan) != nil,
+					sanity.NonNegative("f", nan) != nil,
+					sanity.Negative("f", nan) != nil,
+					sanity.NonPositive("f", nan) != nil,
+				}
+			},
+			expected: []bool{true, true, true, true},
+		},
+		{
+			name: "Messages",
+			function: func() interface{} {
+				verbose := sanity.NonNegative("retries", -2).Error()
+				return []interface{}{
+					verbose == "retries: must be non-negative, got -2" || sanity.RedactBuild,
+					sanity.Redacted(sanity.Positive("workers", 0)).Error(),
+					sanity.WithPrefix("pool", sanity.Positive("size", 0)).(sanity.FieldError).FieldName(),
+				}
+			},
+			expected: []interface{}{true, "workers: must be positive", "pool.size"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package sanity
+
+import (
+	"cmp"
+	"slices"
+)
+
+// InitSliceIfNil sets *p to an empty, non-nil slice with capacity capHint if
+// it is nil, for values that must encode as [] rather than null. A negative
+// capHint is treated as 0.
+func InitSliceIfNil[T any](p *[]T, capHint int) {
+	if *p == nil {
+		*p = make([]T, 0, max(capHint, 0))
+	}
+}
+
+// TruncateSlice shortens *p to at most max elements, so over-long input can be
+// normalized instead of rejected; max < 0 is treated as 0. The dropped tail is
+// zeroed so it does not keep referenced values alive. It returns the number of
+// elements dropped, for logging.
+//
+//	if n := sanity.TruncateSlice(&cfg.Hosts, 16); n > 0 {
+//		log.Printf("ignoring %d extra hosts", n)
+//	}
+func TruncateSlice[T any](p *[]T, max int) (dropped int) {
+	s := *p
+	if len(s) <= max {
+		return 0
+	}
+	if max < 0 {
+		max = 0
+	}
+	clear(s[max:])
+	*p = s[:max]
+	return len(s) - max
+}
+
+// dedupeLinear is the length up to which DedupeSlice compares pairwise
+// instead of allocating a set.
+const dedupeLinear = 32
+
+// DedupeSlice removes repeated elements of *p in place, keeping the first
+// occurrence of each and the original order. Short slices are deduplicated
+// without allocating.
+func DedupeSlice[T comparable](p *[]T) {
+	s := *p
+	out := s[:0]
+	if len(s) <= dedupeLinear {
+	next:
+		for _, v := range s {
+			for _, w := range out {
+				if v == w {
+					continue next
+				}
+			}
+			out = append(out, v)
+		}
+	} else {
+		seen := make(map[T]struct{}, len(s))
+		for _, v := range s {
+			if _, dup := seen[v]; !dup {
+				seen[v] = struct{}{}
+				out = append(out, v)
+			}
+		}
+	}
+	clear(s[len(out):])
+	*p = out
+}
+
+// CompactZeros removes the zero elements of *p ("" hosts, 0 ports) in place,
+// keeping the order of the rest.
+func CompactZeros[T comparable](p *[]T) {
+	var zero T
+	*p = slices.DeleteFunc(*p, func(v T) bool { return v == zero })
+}
+
+// SortedUnique sorts *p and removes repeated elements in place, for list
+// settings whose order carries no meaning.
+func SortedUnique[T cmp.Ordered](p *[]T) {
+	slices.Sort(*p)
+	*p = slices.Compact(*p)
+}
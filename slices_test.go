@@ -0,0 +1,102 @@
+package sanity_test
+
+import (
+	"testing"
+
+	"github.com/sessaidi/sanity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceSanitizers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "InitSliceIfNil",
+			function: func() interface{} {
+				var s []int
+				sanity.InitSliceIfNil(&s, 8)
+				kept := []int{1}
+				sanity.InitSliceIfNil(&kept, 8)
+				var neg []int
+				sanity.InitSliceIfNil(&neg, -1)
+				return []interface{}{s != nil, len(s), cap(s), kept, neg != nil}
+			},
+			expected: []interface{}{true, 0, 8, []int{1}, true},
+		},
+		{
+			name: "TruncateSlice drops the tail",
+			function: func() interface{} {
+				s := []string{"a", "b", "c", "d"}
+				full := s[:4]
+				n := sanity.TruncateSlice(&s, 2)
+				return []interface{}{n, s, full}
+			},
+			expected: []interface{}{2, []string{"a", "b"}, []string{"a", "b", "", ""}},
+		},
+		{
+			name: "TruncateSlice short, nil and negative max",
+			function: func() interface{} {
+				s := []int{1, 2}
+				var nilS []int
+				neg := []int{1}
+				return []interface{}{
+					sanity.TruncateSlice(&s, 2), s,
+					sanity.TruncateSlice(&nilS, 0), nilS == nil,
+					sanity.TruncateSlice(&neg, -1), neg,
+				}
+			},
+			expected: []interface{}{0, []int{1, 2}, 0, true, 1, []int{}},
+		},
+		{
+			name: "DedupeSlice keeps first occurrences in order",
+			function: func() interface{} {
+				s := []string{"b", "a", "b", "c", "a"}
+				full := s[:5]
+				sanity.DedupeSlice(&s)
+				return []interface{}{s, full}
+			},
+			expected: []interface{}{[]string{"b", "a", "c"}, []string{"b", "a", "c", "", ""}},
+		},
+		{
+			name: "DedupeSlice on long slices",
+			function: func() interface{} {
+				s := make([]int, 100)
+				for i := range s {
+					s[i] = i % 40
+				}
+				sanity.DedupeSlice(&s)
+				return []int{len(s), s[0], s[39]}
+			},
+			expected: []int{40, 0, 39},
+		},
+		{
+			name: "CompactZeros",
+			function: func() interface{} {
+				s := []string{"", "a", "", "b", ""}
+				var nilS []int
+				sanity.CompactZeros(&s)
+				sanity.CompactZeros(&nilS)
+				return []interface{}{s, nilS == nil}
+			},
+			expected: []interface{}{[]string{"a", "b"}, true},
+		},
+		{
+			name: "SortedUnique",
+			function: func() interface{} {
+				s := []string{"c", "a", "b", "a", "c"}
+				sanity.SortedUnique(&s)
+				return s
+			},
+			expected: []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package sanity
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+)
+
+// LogValue implementations let slog log errors as structured groups, e.g.
+// err.field=port err.code=OUT_OF_RANGE err.min=1 err.max=10 err.got=0.
+// Offending values are left out when redacting.
+
+func (e NotNilError) LogValue() slog.Value          { return logValueOf(e, redacting()) }
+func (e NonZeroError) LogValue() slog.Value         { return logValueOf(e, redacting()) }
+func (e NonEmptyError) LogValue() slog.Value        { return logValueOf(e, redacting()) }
+func (e LenAtLeastError) LogValue() slog.Value      { return logValueOf(e, redacting()) }
+func (e LenAtMostError) LogValue() slog.Value       { return logValueOf(e, redacting()) }
+func (e LenBetweenError) LogValue() slog.Value      { return logValueOf(e, redacting()) }
+func (e OutOfRangeError[T]) LogValue() slog.Value   { return logValueOf(e, redacting()) }
+func (e NotInSetError) LogValue() slog.Value        { return logValueOf(e, redacting()) }
+func (e InvalidAddrError) LogValue() slog.Value     { return logValueOf(e, redacting()) }
+func (e InvalidURLError) LogValue() slog.Value      { return logValueOf(e, redacting()) }
+func (e FormatError) LogValue() slog.Value          { return logValueOf(e, redacting()) }
+func (e PatternError) LogValue() slog.Value         { return logValueOf(e, redacting()) }
+func (e ElementError) LogValue() slog.Value         { return logValueOf(e, redacting()) }
+func (e MissingKeyError) LogValue() slog.Value      { return logValueOf(e, redacting()) }
+func (e SignError[T]) LogValue() slog.Value         { return logValueOf(e, redacting()) }
+func (e DivisibilityError[T]) LogValue() slog.Value { return logValueOf(e, redacting()) }
+func (e CrossFieldError) LogValue() slog.Value      { return logValueOf(e, redacting()) }
+func (e PredicateError) LogValue() slog.Value       { return logValueOf(e, redacting()) }
+func (e TimeRangeError) LogValue() slog.Value       { return logValueOf(e, redacting()) }
+func (e AdjustedError) LogValue() slog.Value        { return logValueOf(e, redacting()) }
+func (e FieldPathError) LogValue() slog.Value       { return logValueOf(e, redacting()) }
+func (e RepeatedError) LogValue() slog.Value        { return logValueOf(e, redacting()) }
+func (e CallerError) LogValue() slog.Value          { return logValueOf(e, redacting()) }
+func (e renderedError) LogValue() slog.Value        { return logValueOf(e, e.opts.Redact || redacting()) }
+
+func (e ErrorsClampedError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("msg", e.Error()),
+		slog.String("code", CodeErrorsClamped),
+		slog.Int("kept", e.Kept),
+		slog.Int("dropped", e.Dropped),
+	)
+}
+
+func (e ContractError) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("msg", e.Error()), slog.String("code", e.Code())}
+	if e.Err != nil {
+		attrs = append(attrs, slog.Any("err", e.Err))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue logs the panic message and the stack captured at recovery.
+func (e PanicError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("msg", e.Error()),
+		slog.String("code", CodePanic),
+		slog.String("stack", string(e.Stack)),
+	)
+}
+
+func (e AbortedError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("msg", e.Error()),
+		slog.String("code", CodeAborted),
+		slog.Any("err", e.Err),
+	)
+}
+
+func (e CheckError) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("msg", e.Error()), slog.String("check", e.Check)}
+	if code := CodeOf(e.Err); code != "" {
+		attrs = append(attrs, slog.String("code", code))
+	}
+	return slog.GroupValue(append(attrs, slog.Any("err", e.Err))...)
+}
+
+// LogValue logs the aggregate as a count followed by its members keyed by index.
+func (m *multiError) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, m.Len()+1)
+	attrs = append(attrs, slog.Int("count", m.Len()))
+	m.Iter(func(e error) bool {
+		attrs = append(attrs, slog.Any(strconv.Itoa(len(attrs)-1), e))
+		return true
+	})
+	return slog.GroupValue(attrs...)
+}
+
+// valueCarrier is implemented by errors that carry the offending string.
+type valueCarrier interface {
+	gotValue() any
+}
+
+func (e InvalidAddrError) gotValue() any { return e.Got }
+func (e InvalidURLError) gotValue() any  { return e.Got }
+func (e FormatError) gotValue() any      { return e.Got }
+func (e PatternError) gotValue() any     { return e.Got }
+
+func logValueOf(err error, redact bool) slog.Value {
+	attrs := make([]slog.Attr, 0, 6)
+	attrs = append(attrs, slog.String("msg", err.Error()))
+	var fe FieldError
+	if errors.As(err, &fe) {
+		attrs = append(attrs, slog.String("field", fe.FieldName()))
+	}
+	if code := CodeOf(err); code != "" {
+		attrs = append(attrs, slog.String("code", code))
+	}
+	var re RangeError
+	var lb lenBound
+	var vc valueCarrier
+	switch {
+	case errors.As(err, &re):
+		min, max := re.Bounds()
+		attrs = append(attrs, slog.Any("min", min), slog.Any("max", max))
+		if !redact {
+			attrs = append(attrs, slog.Any("got", re.Value()))
+		}
+	case errors.As(err, &lb):
+		want, got := lb.lenWantGot()
+		attrs = append(attrs, slog.Int("want", want))
+		if !redact {
+			attrs = append(attrs, slog.Int("got", got))
+		}
+	case errors.As(err, &vc):
+		if !redact {
+			attrs = append(attrs, slog.Any("got", vc.gotValue()))
+		}
+	}
+	var se SetError
+	if errors.As(err, &se) && len(se.AllowedValues()) > 0 {
+		attrs = append(attrs, slog.Any("allowed", se.AllowedValues()))
+	}
+	var rep RepeatedError
+	if errors.As(err, &rep) {
+		attrs = append(attrs, slog.Int("count", rep.Count))
+	}
+	var ce CallerError
+	if errors.As(err, &ce) {
+		attrs = append(attrs, slog.String("caller", ce.Caller()))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,123 @@
+package sanity_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+// logged returns the "err" attribute of one JSON log record.
+func logged(err error) any {
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Error("config invalid", "err", err)
+	var rec map[string]any
+	if e := json.Unmarshal(buf.Bytes(), &rec); e != nil {
+		return e
+	}
+	return rec["err"]
+}
+
+func TestSlog(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Range error logs bounds and value",
+			function: func() interface{} {
+				m := logged(sanity.InRangeNum("port", 0, 1, 10)).(map[string]any)
+				return []any{m["field"], m["code"], m["min"], m["max"], m["got"] != nil || sanity.RedactBuild}
+			},
+			expected: []any{"port", "OUT_OF_RANGE", 1.0, 10.0, true},
+		},
+		{
+			name: "Set error logs allowed values",
+			function: func() interface{} {
+				m := logged(sanity.OneOf("mode", "x", "auto", "manual")).(map[string]any)
+				return []any{m["code"], m["allowed"]}
+			},
+			expected: []any{"NOT_IN_SET", []any{"auto", "manual"}},
+		},
+		{
+			name: "Redaction omits got",
+			function: func() interface{} {
+				m := logged(sanity.Redacted(sanity.StrLenAtLeast("pw", "ab", 8))).(map[string]any)
+				_, hasGot := m["got"]
+				return []any{m["msg"], m["want"], hasGot}
+			},
+			expected: []any{"pw: len must be >= 8", 8.0, false},
+		},
+		{
+			name: "Aggregate logs count and members",
+			function: func() interface{} {
+				g := sanity.NewGuard(sanity.WithMaxErrors(2), sanity.WithDedup())
+				g.Add(sanity.NonEmpty("name", ""))
+				g.Add(sanity.NonEmpty("name", ""))
+				g.Add(sanity.ValidIP("ip", "x"))
+				g.Add(sanity.NonZero("n", 0))
+				m := logged(g.Err()).(map[string]any)
+				first := m["0"].(map[string]any)
+				second := m["1"].(map[string]any)
+				clamp := m["2"].(map[string]any)
+				return []any{m["count"], first["field"], first["count"], second["code"], clamp["dropped"]}
+			},
+			expected: []any{3.0, "name", 2.0, "INVALID_IP", 1.0},
+		},
+		{
+			name: "Contract error logs kind code and detail",
+			function: func() interface{} {
+				m := logged(sanity.Precondition(false, errors.New("n < 0"))).(map[string]any)
+				return []any{m["msg"], m["code"], m["err"]}
+			},
+			expected: []any{"precondition failed: n < 0", sanity.CodePrecondition, "n < 0"},
+		},
+		{
+			name: "Panic error logs message and stack",
+			function: func() interface{} {
+				m := logged(sanity.CatchPanic(func() error { panic("boom") })).(map[string]any)
+				stack, _ := m["stack"].(string)
+				return []any{m["msg"], m["code"], strings.Contains(stack, "goroutine")}
+			},
+			expected: []any{"panic: boom", "PANIC", true},
+		},
+		{
+			name: "Aborted error logs its cause",
+			function: func() interface{} {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				g := sanity.NewGuard()
+				g.RunCtx(ctx, func(context.Context) error { return nil })
+				m := logged(g.Err()).(map[string]any)
+				return []any{m["msg"], m["code"], m["err"]}
+			},
+			expected: []any{"aborted: context canceled", "ABORTED", "context canceled"},
+		},
+		{
+			name: "Check error logs the check and a structured cause",
+			function: func() interface{} {
+				g := sanity.NewGuard()
+				g.Named("dns").Add(sanity.ContractError{Kind: sanity.ErrInvariant})
+				m := logged(g.Err()).(map[string]any)
+				inner, _ := m["err"].(map[string]any)
+				return []any{m["check"], m["code"], inner["code"]}
+			},
+			expected: []any{"dns", sanity.CodeInvariant, sanity.CodeInvariant},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package sanity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// NullOr returns n.V if n is valid (non-NULL), otherwise def.
+func NullOr[T any](n sql.Null[T], def T) T {
+	if n.Valid {
+		return n.V
+	}
+	return def
+}
+
+// NullStringOr returns ns.String if ns is valid, otherwise def.
+func NullStringOr(ns sql.NullString, def string) string {
+	if ns.Valid {
+		return ns.String
+	}
+	return def
+}
+
+// NullInt64Or returns n.Int64 if n is valid, otherwise def.
+func NullInt64Or(n sql.NullInt64, def int64) int64 {
+	if n.Valid {
+		return n.Int64
+	}
+	return def
+}
+
+// NullFloat64Or returns n.Float64 if n is valid, otherwise def.
+func NullFloat64Or(n sql.NullFloat64, def float64) float64 {
+	if n.Valid {
+		return n.Float64
+	}
+	return def
+}
+
+// NullBoolOr returns n.Bool if n is valid, otherwise def.
+func NullBoolOr(n sql.NullBool, def bool) bool {
+	if n.Valid {
+		return n.Bool
+	}
+	return def
+}
+
+// NullTimeOr returns n.Time if n is valid, otherwise def.
+func NullTimeOr(n sql.NullTime, def time.Time) time.Time {
+	if n.Valid {
+		return n.Time
+	}
+	return def
+}
+
+// NotNull checks that n is not NULL, reporting a NotNilError otherwise.
+func NotNull[T any](name string, n sql.Null[T]) error {
+	if !n.Valid {
+		return NotNilError{Field: name}
+	}
+	return nil
+}
+
+// NotNullString checks that ns is not NULL, reporting a NotNilError otherwise.
+// An empty but valid string passes; combine with NonBlank to reject it too.
+func NotNullString(name string, ns sql.NullString) error {
+	if !ns.Valid {
+		return NotNilError{Field: name}
+	}
+	return nil
+}
+
+// FromPtr converts a nullable pointer to sql.Null: nil becomes NULL.
+func FromPtr[T any](p *T) sql.Null[T] {
+	if p == nil {
+		return sql.Null[T]{}
+	}
+	return sql.Null[T]{V: *p, Valid: true}
+}
+
+// ToPtr converts n to a pointer to a copy of its value, or nil if n is NULL.
+func ToPtr[T any](n sql.Null[T]) *T {
+	if !n.Valid {
+		return nil
+	}
+	return &n.V
+}
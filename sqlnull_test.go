@@ -0,0 +1,71 @@
+package sanity_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestSQLNull(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Null*Or return the value or the default",
+			function: func() interface{} {
+				return []interface{}{
+					sanity.NullStringOr(sql.NullString{String: "a", Valid: true}, "d"),
+					sanity.NullStringOr(sql.NullString{String: "a"}, "d"),
+					sanity.NullInt64Or(sql.NullInt64{}, 7),
+					sanity.NullFloat64Or(sql.NullFloat64{Float64: 0.5, Valid: true}, 1),
+					sanity.NullBoolOr(sql.NullBool{}, true),
+					sanity.NullTimeOr(sql.NullTime{}, t0),
+					sanity.NullOr(sql.Null[time.Duration]{V: time.Second, Valid: true}, time.Minute),
+					sanity.NullOr(sql.Null[int]{}, 3),
+				}
+			},
+			expected: []interface{}{"a", "d", int64(7), 0.5, true, t0, time.Second, 3},
+		},
+		{
+			name: "NotNull and NotNullString report NotNilError",
+			function: func() interface{} {
+				err := sanity.NotNullString("email", sql.NullString{})
+				return []interface{}{
+					errors.Is(err, sanity.ErrNotNil),
+					fieldOf(err),
+					sanity.NotNullString("email", sql.NullString{Valid: true}) == nil,
+					errors.Is(sanity.NotNull("age", sql.Null[int]{}), sanity.ErrNotNil),
+					sanity.NotNull("age", sql.Null[int]{Valid: true}) == nil,
+				}
+			},
+			expected: []interface{}{true, "email", true, true, true},
+		},
+		{
+			name: "FromPtr and ToPtr round-trip",
+			function: func() interface{} {
+				n := sanity.FromPtr(sanity.Ptr(42))
+				var nilPtr *int
+				return []interface{}{
+					n,
+					sanity.FromPtr(nilPtr),
+					*sanity.ToPtr(n),
+					sanity.ToPtr(sql.Null[int]{V: 1}) == nil,
+				}
+			},
+			expected: []interface{}{sql.Null[int]{V: 42, Valid: true}, sql.Null[int]{}, 42, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package sanity
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TrimCollapse trims leading/trailing whitespace and collapses each internal
+// run of Unicode whitespace to a single space. Clean input is returned as is.
+func TrimCollapse(s string) string {
+	if !needsCollapse(s) {
+		return s
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func needsCollapse(s string) bool {
+	prevSpace := true // a leading space needs trimming
+	for _, r := range s {
+		sp := unicode.IsSpace(r)
+		if sp && (prevSpace || r != ' ') {
+			return true
+		}
+		prevSpace = sp
+	}
+	return prevSpace && s != ""
+}
+
+// StripControl removes Unicode control characters (including \n, \r and \t).
+// Input without any is returned as is.
+func StripControl(s string) string {
+	if strings.IndexFunc(s, unicode.IsControl) < 0 {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// TruncateRunes returns the first n runes of s (never splitting a multibyte
+// character); n <= 0 yields "".
+func TruncateRunes(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if len(s) <= n {
+		return s
+	}
+	i := 0
+	for j := range s {
+		if i == n {
+			return s[:j]
+		}
+		i++
+	}
+	return s
+}
+
+// TruncateString shortens *p to at most max bytes, backing up to the start of
+// a multibyte character rather than splitting it, so over-long input can be
+// normalized instead of rejected; max < 0 is treated as 0. It reports whether
+// *p was shortened, for logging.
+//
+//	if sanity.TruncateString(&req.Comment, 4096) {
+//		log.Printf("comment truncated")
+//	}
+func TruncateString(p *string, max int) (truncated bool) {
+	s := *p
+	if len(s) <= max {
+		return false
+	}
+	if max < 0 {
+		max = 0
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	*p = s[:max]
+	return true
+}
+
+// SanitizeLine makes s safe to embed in a single log line: control and
+// whitespace characters (including line separators) become spaces, invisible
+// format characters such as bidi overrides are dropped, invalid UTF-8 is
+// replaced with U+FFFD, and the result is passed through TrimCollapse.
+func SanitizeLine(s string) string {
+	clean := utf8.ValidString(s)
+	for _, r := range s {
+		if !clean || unicode.IsControl(r) || unicode.Is(unicode.Cf, r) || (r != ' ' && unicode.IsSpace(r)) {
+			clean = false
+			break
+		}
+	}
+	if clean {
+		return TrimCollapse(s)
+	}
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.Is(unicode.Cf, r):
+			return -1
+		case unicode.IsControl(r), unicode.IsSpace(r):
+			return ' '
+		}
+		return r
+	}, strings.ToValidUTF8(s, "�"))
+	return TrimCollapse(s)
+}
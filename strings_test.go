@@ -0,0 +1,96 @@
+package sanity_test
+
+import (
+	"testing"
+
+	"github.com/sessaidi/sanity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringSanitizers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name:     "TrimCollapse",
+			function: func() interface{} { return sanity.TrimCollapse("  a \t b\n\nc  ") },
+			expected: "a b c",
+		},
+		{
+			name:     "TrimCollapse clean",
+			function: func() interface{} { return sanity.TrimCollapse("a b") },
+			expected: "a b",
+		},
+		{
+			name:     "TrimCollapse trailing space",
+			function: func() interface{} { return sanity.TrimCollapse("a ") },
+			expected: "a",
+		},
+		{
+			name:     "TrimCollapse blank",
+			function: func() interface{} { return sanity.TrimCollapse(" \t ") },
+			expected: "",
+		},
+		{
+			name:     "StripControl",
+			function: func() interface{} { return sanity.StripControl("a\x00b\r\nc\u0085") },
+			expected: "abc",
+		},
+		{
+			name:     "StripControl clean",
+			function: func() interface{} { return sanity.StripControl("héllo") },
+			expected: "héllo",
+		},
+		{
+			name:     "TruncateRunes multibyte",
+			function: func() interface{} { return sanity.TruncateRunes("héllo wörld", 7) },
+			expected: "héllo w",
+		},
+		{
+			name:     "TruncateRunes short",
+			function: func() interface{} { return sanity.TruncateRunes("日本語", 3) },
+			expected: "日本語",
+		},
+		{
+			name:     "TruncateRunes zero",
+			function: func() interface{} { return sanity.TruncateRunes("abc", 0) },
+			expected: "",
+		},
+		{
+			name:     "SanitizeLine newlines and bidi",
+			function: func() interface{} { return sanity.SanitizeLine(" user\ninjected\u202e x ") },
+			expected: "user injected x",
+		},
+		{
+			name:     "SanitizeLine invalid UTF-8",
+			function: func() interface{} { return sanity.SanitizeLine("a\xffb") },
+			expected: "a�b",
+		},
+		{
+			name:     "SanitizeLine clean",
+			function: func() interface{} { return sanity.SanitizeLine("plain value") },
+			expected: "plain value",
+		},
+		{
+			name: "TruncateString",
+			function: func() interface{} {
+				a, b, c, d := "hello", "héllo", "日本語", "abc"
+				return []interface{}{
+					sanity.TruncateString(&a, 3), a,
+					sanity.TruncateString(&b, 2), b,
+					sanity.TruncateString(&c, 9), c,
+					sanity.TruncateString(&d, -1), d,
+				}
+			},
+			expected: []interface{}{true, "hel", true, "h", false, "日本語", true, ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package sanity
+
+import "encoding/json"
+
+// UnmarshalJSONValidated decodes data into v (a pointer to struct), fills
+// zero fields from `default` tags with ApplyDefaults, and validates the
+// result with ValidateStruct(v, opts...).
+//
+// Decode and default-tag errors are returned as is; otherwise the result is
+// the validation aggregate, or nil. Defaults are applied after decoding, so
+// they never override values present in data.
+func UnmarshalJSONValidated(data []byte, v any, opts ...GuardOption) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if err := ApplyDefaults(v); err != nil {
+		return err
+	}
+	return ValidateStruct(v, opts...)
+}
@@ -0,0 +1,78 @@
+package sanity_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+type jsonConfig struct {
+	Port    int           `json:"port" default:"8080" sanity:"min=1,max=65535"`
+	Mode    string        `json:"mode" default:"auto" sanity:"oneof=auto|manual"`
+	Timeout time.Duration `json:"timeout" default:"5s" sanity:"min=1s,max=1m"`
+	Hosts   []string      `json:"hosts" sanity:"nonempty"`
+}
+
+func TestUnmarshalJSONValidated(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Defaults fill what JSON omits",
+			function: func() interface{} {
+				var c jsonConfig
+				err := sanity.UnmarshalJSONValidated([]byte(`{"mode":"manual","hosts":["a"]}`), &c)
+				return []interface{}{err == nil, c}
+			},
+			expected: []interface{}{
+				true,
+				jsonConfig{Port: 8080, Mode: "manual", Timeout: 5 * time.Second, Hosts: []string{"a"}},
+			},
+		},
+		{
+			name: "Validation aggregate after defaulting",
+			function: func() interface{} {
+				var c jsonConfig
+				err := sanity.UnmarshalJSONValidated([]byte(`{"port":70000,"mode":"x"}`), &c)
+				return fieldsOf(err)
+			},
+			expected: []string{"port", "mode", "hosts"},
+		},
+		{
+			name: "Guard options apply",
+			function: func() interface{} {
+				var c jsonConfig
+				err := sanity.UnmarshalJSONValidated([]byte(`{"port":70000,"mode":"x"}`), &c, sanity.WithMaxErrors(1))
+				n, _ := sanity.GroupLen(err)
+				return n
+			},
+			expected: 2, // first error + clamp sentinel
+		},
+		{
+			name: "Decode and target errors returned as is",
+			function: func() interface{} {
+				var c jsonConfig
+				var se *json.SyntaxError
+				var n int
+				return []bool{
+					errors.As(sanity.UnmarshalJSONValidated([]byte(`{`), &c), &se),
+					errors.Is(sanity.UnmarshalJSONValidated([]byte(`1`), &n), sanity.ErrNotStruct),
+				}
+			},
+			expected: []bool{true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Struct-tag sentinels (programming errors, returned immediately rather than aggregated).
+var (
+	ErrBadTag    = errors.New("sanity:bad_tag")
+	ErrNotStruct = errors.New("sanity:not_struct")
+)
+
+// ValidateStruct validates the exported fields of a struct (or pointer to
+// struct) from their `sanity:"..."` tags and returns the aggregate of all
+// failures (unlimited by default; opts configure the Guard). Rules:
+//
+//	notnil        pointer, map, slice, interface, func or chan must be non-nil
+//	nonzero       value must not be the zero value
+//	nonempty      string, slice, map or array must be non-empty
+//	nonblank      string must contain non-space characters
+//	minlen=N      len must be >= N
+//	min=X,max=Y   numeric (or time.Duration, e.g. min=1s) range, inclusive
+//	oneof=a|b|c   value (formatted with %v) must be one of the listed values
+//	-             skip the field
+//
+// Only the first failing rule of each field is reported. Nested structs and
+// non-nil pointers to structs are validated recursively with "parent.child"
+// field paths; the field name is taken from the json tag when present.
+func ValidateStruct(v any, opts ...GuardOption) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return fmt.Errorf("sanity: ValidateStruct(nil %T): %w", v, ErrNotStruct)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("sanity: ValidateStruct(%T): %w", v, ErrNotStruct)
+	}
+	g := NewGuard(append([]GuardOption{WithMaxErrors(0)}, opts...)...)
+	if err := validateStructValue(&g, "", rv); err != nil {
+		return err
+	}
+	return g.Err()
+}
+
+func validateStructValue(g *Guard, prefix string, rv reflect.Value) error {
+	rules, err := structRulesFor(rv.Type())
+	if err != nil {
+		return err
+	}
+	for i := range rules {
+		r := &rules[i]
+		fv := rv.Field(r.index)
+		path := JoinPath(prefix, r.name)
+		if r.embedded {
+			path = prefix
+		}
+		if err := r.check(path, fv); err != nil {
+			g.Add(err)
+			continue
+		}
+		if sv, ok := nestedStruct(fv); ok {
+			if err := validateStructValue(g, path, sv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func nestedStruct(fv reflect.Value) (reflect.Value, bool) {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return fv, false
+		}
+		fv = fv.Elem()
+	}
+	return fv, fv.Kind() == reflect.Struct
+}
+
+// fieldRule is the parsed form of one field's sanity tag.
+type fieldRule struct {
+	index    int
+	name     string
+	embedded bool
+
+	notNil, nonZero, nonEmpty, nonBlank bool
+
+	minLen int // < 0 => unset
+
+	hasMin, hasMax bool
+	minI, maxI     int64
+	minU, maxU     uint64
+	minF, maxF     float64
+
+	oneOf []string
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+var structRulesCache sync.Map // reflect.Type -> []fieldRule
+
+func structRulesFor(t reflect.Type) ([]fieldRule, error) {
+	if c, ok := structRulesCache.Load(t); ok {
+		return c.([]fieldRule), nil
+	}
+	var rules []fieldRule
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("sanity")
+		if tag == "-" {
+			continue
+		}
+		r := fieldRule{index: i, name: fieldNameOf(sf), embedded: sf.Anonymous, minLen: -1}
+		if err := r.parse(tag, sf.Type); err != nil {
+			return nil, fmt.Errorf("sanity: %s.%s: %w", t.Name(), sf.Name, err)
+		}
+		rules = append(rules, r)
+	}
+	structRulesCache.Store(t, rules)
+	return rules, nil
+}
+
+func fieldNameOf(sf reflect.StructField) string {
+	if j := sf.Tag.Get("json"); j != "" {
+		if name, _, _ := strings.Cut(j, ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return sf.Name
+}
+
+func (r *fieldRule) parse(tag string, t reflect.Type) error {
+	if tag == "" {
+		return nil
+	}
+	for _, part := range strings.Split(tag, ",") {
+		key, val, hasVal := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "minlen", "min", "max", "oneof":
+			if !hasVal {
+				return fmt.Errorf("rule %q needs a value: %w", key, ErrBadTag)
+			}
+		}
+		var err error
+		switch key {
+		case "notnil":
+			r.notNil = true
+		case "nonzero":
+			r.nonZero = true
+		case "nonempty":
+			r.nonEmpty = true
+		case "nonblank":
+			r.nonBlank = true
+		case "minlen":
+			r.minLen, err = strconv.Atoi(val)
+		case "min":
+			r.hasMin = true
+			err = r.parseBound(val, t, &r.minI, &r.minU, &r.minF)
+		case "max":
+			r.hasMax = true
+			err = r.parseBound(val, t, &r.maxI, &r.maxU, &r.maxF)
+		case "oneof":
+			r.oneOf = strings.Split(val, "|")
+		default:
+			return fmt.Errorf("unknown rule %q: %w", key, ErrBadTag)
+		}
+		if err != nil {
+			return fmt.Errorf("rule %q: %v: %w", part, err, ErrBadTag)
+		}
+	}
+	return nil
+}
+
+func (r *fieldRule) parseBound(s string, t reflect.Type, i *int64, u *uint64, f *float64) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var err error
+	switch {
+	case t == durationType:
+		var d time.Duration
+		d, err = time.ParseDuration(s)
+		*i = int64(d)
+	case isIntKind(t.Kind()):
+		*i, err = strconv.ParseInt(s, 10, 64)
+	case isUintKind(t.Kind()):
+		*u, err = strconv.ParseUint(s, 10, 64)
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		*f, err = strconv.ParseFloat(s, 64)
+	default:
+		err = fmt.Errorf("min/max on non-numeric %s", t)
+	}
+	return err
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func (r *fieldRule) check(name string, fv reflect.Value) error {
+	k := fv.Kind()
+	if r.notNil {
+		switch k {
+		case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+			if fv.IsNil() {
+				return NotNilError{Field: name}
+			}
+		}
+	}
+	if r.nonZero && fv.IsZero() {
+		return NonZeroError{Field: name}
+	}
+	// Optional fields: a nil pointer passes the value rules; otherwise they apply to the pointee.
+	for k == reflect.Pointer {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+		k = fv.Kind()
+	}
+	if r.nonEmpty {
+		switch k {
+		case reflect.String:
+			if fv.Len() == 0 {
+				return NonEmptyError{Field: name}
+			}
+		case reflect.Slice, reflect.Map, reflect.Array:
+			if fv.Len() == 0 {
+				return LenAtLeastError{Field: name, Want: 1, Got: 0}
+			}
+		}
+	}
+	if r.nonBlank && k == reflect.String {
+		if err := NonBlank(name, fv.String()); err != nil {
+			return err
+		}
+	}
+	if r.minLen >= 0 {
+		switch k {
+		case reflect.String, reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+			if fv.Len() < r.minLen {
+				return LenAtLeastError{Field: name, Want: r.minLen, Got: fv.Len()}
+			}
+		}
+	}
+	if r.hasMin || r.hasMax {
+		if err := r.checkRange(name, fv); err != nil {
+			return err
+		}
+	}
+	if r.oneOf != nil {
+		if err := OneOf(name, fmt.Sprint(fv.Interface()), r.oneOf...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fieldRule) checkRange(name string, fv reflect.Value) error {
+	switch k := fv.Kind(); {
+	case fv.Type() == durationType:
+		min, max := time.Duration(math.MinInt64), time.Duration(math.MaxInt64)
+		if r.hasMin {
+			min = time.Duration(r.minI)
+		}
+		if r.hasMax {
+			max = time.Duration(r.maxI)
+		}
+		return InRangeDuration(name, time.Duration(fv.Int()), min, max)
+	case isIntKind(k):
+		min, max := int64(math.MinInt64), int64(math.MaxInt64)
+		if r.hasMin {
+			min = r.minI
+		}
+		if r.hasMax {
+			max = r.maxI
+		}
+		return InRangeNum(name, fv.Int(), min, max)
+	case isUintKind(k):
+		min, max := uint64(0), uint64(math.MaxUint64)
+		if r.hasMin {
+			min = r.minU
+		}
+		if r.hasMax {
+			max = r.maxU
+		}
+		return InRangeNum(name, fv.Uint(), min, max)
+	default:
+		min, max := math.Inf(-1), math.Inf(1)
+		if r.hasMin {
+			min = r.minF
+		}
+		if r.hasMax {
+			max = r.maxF
+		}
+		return InRangeFloat64(name, fv.Float(), min, max)
+	}
+}
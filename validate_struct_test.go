@@ -0,0 +1,161 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+type tlsConfig struct {
+	Cert string `json:"cert" sanity:"nonblank"`
+	Key  string `json:"key" sanity:"nonblank"`
+}
+
+type serverConfig struct {
+	Port    int           `json:"port" sanity:"nonzero,min=1,max=65535"`
+	Mode    string        `json:"mode" sanity:"oneof=auto|manual"`
+	Timeout time.Duration `json:"timeout" sanity:"min=1s,max=1m"`
+	Ratio   float64       `sanity:"min=0,max=1"`
+	Workers uint          `sanity:"max=64"`
+	Hosts   []string      `sanity:"nonempty"`
+	Limit   *int          `sanity:"min=1"`
+	TLS     *tlsConfig    `json:"tls"`
+	Skip    int           `sanity:"-"`
+	hidden  int
+}
+
+func validServer() serverConfig {
+	return serverConfig{Port: 80, Mode: "auto", Timeout: 5 * time.Second, Ratio: 0.5, Workers: 4, Hosts: []string{"a"}}
+}
+
+func fieldsOf(err error) []string {
+	var out []string
+	for _, e := range sanity.GroupAsSlice(err, nil) {
+		out = append(out, fieldOf(e))
+	}
+	return out
+}
+
+func TestValidateStruct(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Valid struct -> nil",
+			function: func() interface{} {
+				c := validServer()
+				return sanity.ValidateStruct(&c) == nil
+			},
+			expected: true,
+		},
+		{
+			name: "Every failing field reported once, with json names",
+			function: func() interface{} {
+				c := serverConfig{Mode: "x", Ratio: 2, Workers: 100, Limit: sanity.Ptr(0), Skip: -1, hidden: -1}
+				return fieldsOf(sanity.ValidateStruct(c))
+			},
+			expected: []string{"port", "mode", "timeout", "Ratio", "Workers", "Hosts", "Limit"},
+		},
+		{
+			name: "Categories map to existing validators",
+			function: func() interface{} {
+				c := validServer()
+				c.Port = 0
+				c.Mode = "x"
+				c.Hosts = nil
+				err := sanity.ValidateStruct(&c)
+				return []bool{
+					errors.Is(err, sanity.ErrNonZero),
+					errors.Is(err, sanity.ErrNotInSet),
+					errors.Is(err, sanity.ErrLenAtLeast),
+					errors.Is(err, sanity.ErrOutOfRange),
+				}
+			},
+			expected: []bool{true, true, true, false},
+		},
+		{
+			name: "oneof tag reports allowed values in tag order",
+			function: func() interface{} {
+				c := validServer()
+				c.Mode = "x"
+				var se sanity.SetError
+				ok := errors.As(sanity.ValidateStruct(&c), &se)
+				return []interface{}{ok, se.AllowedValues()}
+			},
+			expected: []interface{}{true, []string{"auto", "manual"}},
+		},
+		{
+			name: "Duration bounds parsed from tag",
+			function: func() interface{} {
+				c := validServer()
+				c.Timeout = time.Hour
+				var oe sanity.OutOfRangeError[time.Duration]
+				ok := errors.As(sanity.ValidateStruct(&c), &oe)
+				return []interface{}{ok, oe.Min, oe.Max}
+			},
+			expected: []interface{}{true, time.Second, time.Minute},
+		},
+		{
+			name: "Nested pointer struct gets dotted paths",
+			function: func() interface{} {
+				c := validServer()
+				c.TLS = &tlsConfig{Cert: " "}
+				return fieldsOf(sanity.ValidateStruct(&c))
+			},
+			expected: []string{"tls.cert", "tls.key"},
+		},
+		{
+			name: "Guard options apply (first-error)",
+			function: func() interface{} {
+				c := serverConfig{}
+				n, _ := sanity.GroupLen(sanity.ValidateStruct(&c, sanity.WithMaxErrors(1)))
+				return n
+			},
+			expected: 2, // first error + clamp sentinel
+		},
+		{
+			name: "Non-struct input -> ErrNotStruct",
+			function: func() interface{} {
+				var p *serverConfig
+				return []bool{
+					errors.Is(sanity.ValidateStruct(42), sanity.ErrNotStruct),
+					errors.Is(sanity.ValidateStruct(p), sanity.ErrNotStruct),
+				}
+			},
+			expected: []bool{true, true},
+		},
+		{
+			name: "Bad tags -> ErrBadTag",
+			function: func() interface{} {
+				type unknownRule struct {
+					A int `sanity:"positive"`
+				}
+				type missingValue struct {
+					A int `sanity:"min"`
+				}
+				type rangeOnString struct {
+					A string `sanity:"max=3"`
+				}
+				return []bool{
+					errors.Is(sanity.ValidateStruct(unknownRule{}), sanity.ErrBadTag),
+					errors.Is(sanity.ValidateStruct(missingValue{}), sanity.ErrBadTag),
+					errors.Is(sanity.ValidateStruct(rangeOnString{}), sanity.ErrBadTag),
+				}
+			},
+			expected: []bool{true, true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package sanity
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"time"
+)
+
+// Rule validates values of type T. Implementations are used through their zero
+// value, so a Rule is typically an empty struct type carrying fixed bounds.
+type Rule[T any] interface {
+	Check(v T) error
+}
+
+// Validated wraps a T that is checked by R whenever it is decoded, so invalid
+// values fail at unmarshal time instead of entering a config struct.
+type Validated[T any, R Rule[T]] struct {
+	Value T
+}
+
+// SanityValidate runs R against the wrapped value.
+func (v Validated[T, R]) SanityValidate() error {
+	var r R
+	return r.Check(v.Value)
+}
+
+// UnmarshalText decodes text into Value and then runs R.
+func (v *Validated[T, R]) UnmarshalText(text []byte) error {
+	var x T
+	if err := unmarshalTextInto(&x, text); err != nil {
+		return err
+	}
+	var r R
+	if err := r.Check(x); err != nil {
+		return err
+	}
+	v.Value = x
+	return nil
+}
+
+// UnmarshalJSON decodes data into Value and then runs R. JSON strings are also
+// accepted for non-string T via UnmarshalText (e.g. "30s" for a Duration).
+func (v *Validated[T, R]) UnmarshalJSON(data []byte) error {
+	var x T
+	if err := json.Unmarshal(data, &x); err != nil {
+		var s string
+		if len(data) == 0 || data[0] != '"' || json.Unmarshal(data, &s) != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(s))
+	}
+	var r R
+	if err := r.Check(x); err != nil {
+		return err
+	}
+	v.Value = x
+	return nil
+}
+
+// MarshalJSON encodes Value.
+func (v Validated[T, R]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+func unmarshalTextInto[T any](p *T, text []byte) error {
+	switch x := any(p).(type) {
+	case encoding.TextUnmarshaler:
+		return x.UnmarshalText(text)
+	case *string:
+		*x = string(text)
+		return nil
+	case *time.Duration:
+		d, err := time.ParseDuration(string(bytes.TrimSpace(text)))
+		if err != nil {
+			return err
+		}
+		*x = d
+		return nil
+	default:
+		return json.Unmarshal(bytes.TrimSpace(text), p)
+	}
+}
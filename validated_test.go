@@ -0,0 +1,107 @@
+package sanity_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+type portRule struct{}
+
+func (portRule) Check(v int) error { return sanity.InRangeNum("port", v, 1, 65535) }
+
+type timeoutRule struct{}
+
+func (timeoutRule) Check(v time.Duration) error {
+	return sanity.InRangeDuration("timeout", v, time.Second, time.Minute)
+}
+
+type validatedCfg struct {
+	Port    sanity.Validated[int, portRule]              `json:"port"`
+	Timeout sanity.Validated[time.Duration, timeoutRule] `json:"timeout"`
+}
+
+func TestValidated(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "JSON valid values decode",
+			function: func() interface{} {
+				var c validatedCfg
+				err := json.Unmarshal([]byte(`{"port":8080,"timeout":"30s"}`), &c)
+				return []interface{}{err == nil, c.Port.Value, c.Timeout.Value}
+			},
+			expected: []interface{}{true, 8080, 30 * time.Second},
+		},
+		{
+			name: "JSON out-of-range port fails decoding",
+			function: func() interface{} {
+				var c validatedCfg
+				err := json.Unmarshal([]byte(`{"port":0}`), &c)
+				return errors.Is(err, sanity.ErrOutOfRange)
+			},
+			expected: true,
+		},
+		{
+			name: "JSON numeric duration is checked too",
+			function: func() interface{} {
+				var c validatedCfg
+				err := json.Unmarshal([]byte(`{"timeout":1}`), &c)
+				return errors.Is(err, sanity.ErrOutOfRange)
+			},
+			expected: true,
+		},
+		{
+			name: "UnmarshalText parses and checks",
+			function: func() interface{} {
+				var p sanity.Validated[int, portRule]
+				ok := p.UnmarshalText([]byte("443")) == nil
+				bad := errors.Is(p.UnmarshalText([]byte("70000")), sanity.ErrOutOfRange)
+				return []interface{}{ok, bad, p.Value}
+			},
+			expected: []interface{}{true, true, 443},
+		},
+		{
+			name: "UnmarshalText syntax error surfaces",
+			function: func() interface{} {
+				var p sanity.Validated[int, portRule]
+				return p.UnmarshalText([]byte("abc")) != nil
+			},
+			expected: true,
+		},
+		{
+			name: "MarshalJSON round trip",
+			function: func() interface{} {
+				c := validatedCfg{}
+				c.Port.Value = 80
+				c.Timeout.Value = 2 * time.Second
+				b, _ := json.Marshal(c)
+				return string(b)
+			},
+			expected: `{"port":80,"timeout":2000000000}`,
+		},
+		{
+			name: "Sanitize runs the rule on constructed values",
+			function: func() interface{} {
+				p := sanity.Validated[int, portRule]{Value: 0}
+				return errors.Is(sanity.Sanitize(p), sanity.ErrOutOfRange)
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package sanity
 
 import (
+	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"time"
 )
@@ -56,6 +58,57 @@ func MapLenAtLeast[K comparable, V any](name string, m map[K]V, n int) error {
 	return nil
 }
 
+func StrLenAtMost(name string, s string, n int) error {
+	if len(s) > n {
+		return LenAtMostError{Field: name, Want: n, Got: len(s)}
+	}
+	return nil
+}
+
+func StrLenBetween(name string, s string, min, max int) error {
+	if min > max {
+		min, max = max, min
+	}
+	if l := len(s); l < min || l > max {
+		return LenBetweenError{Field: name, Min: min, Max: max, Got: l}
+	}
+	return nil
+}
+
+func SliceLenAtMost[T any](name string, s []T, n int) error {
+	if len(s) > n {
+		return LenAtMostError{Field: name, Want: n, Got: len(s)}
+	}
+	return nil
+}
+
+func SliceLenBetween[T any](name string, s []T, min, max int) error {
+	if min > max {
+		min, max = max, min
+	}
+	if l := len(s); l < min || l > max {
+		return LenBetweenError{Field: name, Min: min, Max: max, Got: l}
+	}
+	return nil
+}
+
+func MapLenAtMost[K comparable, V any](name string, m map[K]V, n int) error {
+	if len(m) > n {
+		return LenAtMostError{Field: name, Want: n, Got: len(m)}
+	}
+	return nil
+}
+
+func MapLenBetween[K comparable, V any](name string, m map[K]V, min, max int) error {
+	if min > max {
+		min, max = max, min
+	}
+	if l := len(m); l < min || l > max {
+		return LenBetweenError{Field: name, Min: min, Max: max, Got: l}
+	}
+	return nil
+}
+
 func InSet[T comparable](name string, v T, set map[T]struct{}) error {
 	if _, ok := set[v]; !ok {
 		return NotInSetError{Field: name}
@@ -63,6 +116,40 @@ func InSet[T comparable](name string, v T, set map[T]struct{}) error {
 	return nil
 }
 
+// InSetVerbose is InSet whose NotInSetError lists the set's values (formatted
+// with %v and sorted), for responses that tell the user what is acceptable.
+// Prefer OneOf for short fixed lists; it keeps the caller's order.
+func InSetVerbose[T comparable](name string, v T, set map[T]struct{}) error {
+	if _, ok := set[v]; ok {
+		return nil
+	}
+	allowed := make([]string, 0, len(set))
+	for k := range set {
+		allowed = append(allowed, fmt.Sprint(k))
+	}
+	slices.Sort(allowed)
+	return notInSet(name, allowed)
+}
+
+// OneOf checks that v equals one of allowed, without building a set; the
+// NotInSetError lists the allowed values (formatted with %v).
+func OneOf[T comparable](name string, v T, allowed ...T) error {
+	for _, a := range allowed {
+		if v == a {
+			return nil
+		}
+	}
+	return notInSet(name, formatAll(allowed))
+}
+
+func formatAll[T any](vs []T) []string {
+	out := make([]string, len(vs))
+	for i, v := range vs {
+		out[i] = fmt.Sprint(v)
+	}
+	return out
+}
+
 func InRangeString(name, v, min, max string) error {
 	if min > max {
 		min, max = max, min
@@ -0,0 +1,145 @@
+package sanity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The *f variants below take the field name as a format string plus args and
+// only call fmt.Sprintf when the check fails, so the name itself is never
+// built on the success path. The args still escape through fmt.Sprintf: the
+// caller boxes each one into an interface, which allocates for most values
+// (ints >= 256, non-constant strings, ...) even when the check passes. On hot
+// paths use the *N variants with Indexed instead, which take the index as an
+// int and do not allocate unless the check fails:
+//
+//	sanity.InRangeNumN(sanity.Indexed("ports", i), p, 1, 65535)
+
+func NotNilPtrf[T any](p *T, format string, args ...any) error {
+	if p == nil {
+		return NotNilError{Field: fmt.Sprintf(format, args...)}
+	}
+	return nil
+}
+
+func NonZerof[T comparable](v T, format string, args ...any) error {
+	var zero T
+	if v == zero {
+		return NonZeroError{Field: fmt.Sprintf(format, args...)}
+	}
+	return nil
+}
+
+func NonEmptyf(s string, format string, args ...any) error {
+	if s == "" {
+		return NonEmptyError{Field: fmt.Sprintf(format, args...)}
+	}
+	return nil
+}
+
+func NonBlankf(s string, format string, args ...any) error {
+	if len(strings.TrimSpace(s)) == 0 {
+		return NonEmptyError{Field: fmt.Sprintf(format, args...)}
+	}
+	return nil
+}
+
+func StrLenAtLeastf(s string, n int, format string, args ...any) error {
+	if len(s) < n {
+		return LenAtLeastError{Field: fmt.Sprintf(format, args...), Want: n, Got: len(s)}
+	}
+	return nil
+}
+
+func SliceLenAtLeastf[T any](s []T, n int, format string, args ...any) error {
+	if len(s) < n {
+		return LenAtLeastError{Field: fmt.Sprintf(format, args...), Want: n, Got: len(s)}
+	}
+	return nil
+}
+
+func MapLenAtLeastf[K comparable, V any](m map[K]V, n int, format string, args ...any) error {
+	if len(m) < n {
+		return LenAtLeastError{Field: fmt.Sprintf(format, args...), Want: n, Got: len(m)}
+	}
+	return nil
+}
+
+func StrLenAtMostf(s string, n int, format string, args ...any) error {
+	if len(s) > n {
+		return LenAtMostError{Field: fmt.Sprintf(format, args...), Want: n, Got: len(s)}
+	}
+	return nil
+}
+
+func StrLenBetweenf(s string, min, max int, format string, args ...any) error {
+	if min > max {
+		min, max = max, min
+	}
+	if l := len(s); l < min || l > max {
+		return LenBetweenError{Field: fmt.Sprintf(format, args...), Min: min, Max: max, Got: l}
+	}
+	return nil
+}
+
+func SliceLenAtMostf[T any](s []T, n int, format string, args ...any) error {
+	if len(s) > n {
+		return LenAtMostError{Field: fmt.Sprintf(format, args...), Want: n, Got: len(s)}
+	}
+	return nil
+}
+
+func SliceLenBetweenf[T any](s []T, min, max int, format string, args ...any) error {
+	if min > max {
+		min, max = max, min
+	}
+	if l := len(s); l < min || l > max {
+		return LenBetweenError{Field: fmt.Sprintf(format, args...), Min: min, Max: max, Got: l}
+	}
+	return nil
+}
+
+func MapLenAtMostf[K comparable, V any](m map[K]V, n int, format string, args ...any) error {
+	if len(m) > n {
+		return LenAtMostError{Field: fmt.Sprintf(format, args...), Want: n, Got: len(m)}
+	}
+	return nil
+}
+
+func MapLenBetweenf[K comparable, V any](m map[K]V, min, max int, format string, args ...any) error {
+	if min > max {
+		min, max = max, min
+	}
+	if l := len(m); l < min || l > max {
+		return LenBetweenError{Field: fmt.Sprintf(format, args...), Min: min, Max: max, Got: l}
+	}
+	return nil
+}
+
+func InSetf[T comparable](v T, set map[T]struct{}, format string, args ...any) error {
+	if _, ok := set[v]; !ok {
+		return NotInSetError{Field: fmt.Sprintf(format, args...)}
+	}
+	return nil
+}
+
+func InRangeNumf[T Numeric](v, min, max T, format string, args ...any) error {
+	if min > max {
+		min, max = max, min
+	}
+	if v < min || v > max {
+		return OutOfRangeError[T]{Field: fmt.Sprintf(format, args...), Min: min, Max: max, Got: v}
+	}
+	return nil
+}
+
+func InRangeDurationf(v, min, max time.Duration, format string, args ...any) error {
+	if min > max {
+		min, max = max, min
+	}
+	if v < min || v > max {
+		return OutOfRangeError[time.Duration]{Field: fmt.Sprintf(format, args...), Min: min, Max: max, Got: v}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func fieldOf(err error) string {
+	var fe sanity.FieldError
+	if errors.As(err, &fe) {
+		return fe.FieldName()
+	}
+	return ""
+}
+
+func TestValidatorsFormatted(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "NonEmptyf ok -> nil",
+			function: func() interface{} {
+				return sanity.NonEmptyf("x", "items[%d].name", 3) == nil
+			},
+			expected: true,
+		},
+		{
+			name: "NonEmptyf fail -> formatted field",
+			function: func() interface{} {
+				return fieldOf(sanity.NonEmptyf("", "items[%d].name", 3))
+			},
+			expected: "items[3].name",
+		},
+		{
+			name: "NonBlankf fail",
+			function: func() interface{} {
+				return fieldOf(sanity.NonBlankf("  ", "%s.mode", "server"))
+			},
+			expected: "server.mode",
+		},
+		{
+			name: "NotNilPtrf and NonZerof",
+			function: func() interface{} {
+				return []string{
+					fieldOf(sanity.NotNilPtrf[int](nil, "p%d", 1)),
+					fieldOf(sanity.NonZerof(0, "n%d", 2)),
+				}
+			},
+			expected: []string{"p1", "n2"},
+		},
+		{
+			name: "Len variants keep Want/Got",
+			function: func() interface{} {
+				var le sanity.LenAtLeastError
+				errors.As(sanity.StrLenAtLeastf("ab", 3, "s[%d]", 0), &le)
+				return []interface{}{
+					le,
+					errors.Is(sanity.SliceLenAtLeastf([]int{}, 1, "xs"), sanity.ErrLenAtLeast),
+					errors.Is(sanity.MapLenAtLeastf(map[int]int{}, 1, "m"), sanity.ErrLenAtLeast),
+				}
+			},
+			expected: []interface{}{sanity.LenAtLeastError{Field: "s[0]", Want: 3, Got: 2}, true, true},
+		},
+		{
+			name: "Upper-bound len variants",
+			function: func() interface{} {
+				return []interface{}{
+					sanity.StrLenAtMostf("abc", 2, "s[%d]", 1),
+					sanity.SliceLenBetweenf([]int{1}, 2, 3, "xs[%d]", 2),
+					errors.Is(sanity.SliceLenAtMostf([]int{1, 2}, 1, "xs"), sanity.ErrLenAtMost),
+					errors.Is(sanity.MapLenAtMostf(map[int]int{1: 1}, 0, "m"), sanity.ErrLenAtMost),
+					errors.Is(sanity.StrLenBetweenf("", 1, 2, "s"), sanity.ErrLenBetween),
+					errors.Is(sanity.MapLenBetweenf(map[int]int{}, 1, 2, "m"), sanity.ErrLenBetween),
+				}
+			},
+			expected: []interface{}{
+				sanity.LenAtMostError{Field: "s[1]", Want: 2, Got: 3},
+				sanity.LenBetweenError{Field: "xs[2]", Min: 2, Max: 3, Got: 1},
+				true, true, true, true,
+			},
+		},
+		{
+			name: "InSetf miss",
+			function: func() interface{} {
+				set := map[string]struct{}{"auto": {}}
+				return fieldOf(sanity.InSetf("x", set, "w%d.mode", 1))
+			},
+			expected: "w1.mode",
+		},
+		{
+			name: "InRangeNumf swap and fail",
+			function: func() interface{} {
+				return []interface{}{
+					sanity.InRangeNumf(5, 10, 1, "n") == nil,
+					sanity.InRangeNumf(0, 1, 10, "w%d.i%d", 1, 2),
+				}
+			},
+			expected: []interface{}{true, sanity.OutOfRangeError[int]{Field: "w1.i2", Min: 1, Max: 10, Got: 0}},
+		},
+		{
+			name: "InRangeDurationf fail",
+			function: func() interface{} {
+				return fieldOf(sanity.InRangeDurationf(0, time.Second, 2*time.Second, "d%d", 9))
+			},
+			expected: "d9",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestValidatorsIndexedZeroAlloc(t *testing.T) {
+	i, v := 1<<20, 1<<21 // large enough that boxing them would allocate
+	allocs := testing.AllocsPerRun(100, func() {
+		sinkErr = sanity.InRangeNumN(sanity.Indexed("items", i), v, 0, 1<<22)
+		if sinkErr == nil {
+			sinkErr = sanity.NonEmptyN(sanity.Indexed("names", i), "x")
+		}
+	})
+	assert.Equal(t, 0.0, allocs)
+	assert.Equal(t, "items[1048576]", fieldOf(sanity.InRangeNumN(sanity.Indexed("items", i), -1, 0, 1)))
+}
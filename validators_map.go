@@ -0,0 +1,78 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMissingKey is the category sentinel of MissingKeyError.
+var ErrMissingKey = errors.New("sanity:missing_key")
+
+// MissingKeyError indicates a required map key is absent. Field is the entry
+// path, e.g. "overrides[tenant-a]".
+type MissingKeyError struct {
+	Field string
+}
+
+func (e MissingKeyError) Unwrap() error       { return ErrMissingKey }
+func (e MissingKeyError) FieldName() string   { return e.Field }
+func (e MissingKeyError) Code() string        { return CodeMissingKey }
+func (e MissingKeyError) Error() string       { return e.FieldName() + ": " + e.message(redacting()) }
+func (e MissingKeyError) message(bool) string { return "is required" }
+func (e MissingKeyError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+// keyPath renders the path of map entry k, e.g. "flags[beta]".
+func keyPath[K comparable](name string, k K) string {
+	return name + "[" + fmt.Sprint(k) + "]"
+}
+
+// firstKey returns the offending key with the smallest path, so the reported
+// entry does not depend on map iteration order.
+func firstKey[K comparable, V any](name string, m map[K]V, bad func(K, V) bool) (string, bool) {
+	var path string
+	found := false
+	for k, v := range m {
+		if !bad(k, v) {
+			continue
+		}
+		if p := keyPath(name, k); !found || p < path {
+			path, found = p, true
+		}
+	}
+	return path, found
+}
+
+// MapKeysInSet reports a key of m that is not in set as NotInSetError{Field: "name[key]"}.
+func MapKeysInSet[K comparable, V any](name string, m map[K]V, set map[K]struct{}) error {
+	path, found := firstKey(name, m, func(k K, _ V) bool {
+		_, ok := set[k]
+		return !ok
+	})
+	if found {
+		return NotInSetError{Field: path}
+	}
+	return nil
+}
+
+// MapValuesNonZero reports an entry of m with a zero value as NonZeroError{Field: "name[key]"}.
+func MapValuesNonZero[K, V comparable](name string, m map[K]V) error {
+	var zero V
+	path, found := firstKey(name, m, func(_ K, v V) bool { return v == zero })
+	if found {
+		return NonZeroError{Field: path}
+	}
+	return nil
+}
+
+// MapHasKeys reports the first required key (in argument order) missing from m.
+func MapHasKeys[K comparable, V any](name string, m map[K]V, required ...K) error {
+	for _, k := range required {
+		if _, ok := m[k]; !ok {
+			return MissingKeyError{Field: keyPath(name, k)}
+		}
+	}
+	return nil
+}
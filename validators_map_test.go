@@ -0,0 +1,76 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestMapValidators(t *testing.T) {
+	known := map[string]struct{}{"beta": {}, "dark": {}}
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Valid maps pass",
+			function: func() interface{} {
+				flags := map[string]bool{"beta": true, "dark": true}
+				return []bool{
+					sanity.MapKeysInSet("flags", flags, known) == nil,
+					sanity.MapValuesNonZero("flags", flags) == nil,
+					sanity.MapHasKeys("flags", flags, "beta") == nil,
+					sanity.MapHasKeys[string, bool]("flags", nil) == nil,
+				}
+			},
+			expected: []bool{true, true, true, true},
+		},
+		{
+			name: "MapKeysInSet reports smallest unknown key",
+			function: func() interface{} {
+				flags := map[string]bool{"beta": true, "zeta": true, "alpha": true}
+				err := sanity.MapKeysInSet("flags", flags, known)
+				return []interface{}{fieldOf(err), errors.Is(err, sanity.ErrNotInSet)}
+			},
+			expected: []interface{}{"flags[alpha]", true},
+		},
+		{
+			name: "MapValuesNonZero",
+			function: func() interface{} {
+				err := sanity.MapValuesNonZero("limits", map[int]int{1: 5, 3: 0, 2: 0})
+				return []interface{}{fieldOf(err), errors.Is(err, sanity.ErrNonZero)}
+			},
+			expected: []interface{}{"limits[2]", true},
+		},
+		{
+			name: "MapHasKeys reports first missing in argument order",
+			function: func() interface{} {
+				err := sanity.MapHasKeys("overrides", map[string]int{"a": 1}, "a", "c", "b")
+				return []interface{}{err, errors.Is(err, sanity.ErrMissingKey), sanity.CodeOf(err), err.Error()}
+			},
+			expected: []interface{}{
+				sanity.MissingKeyError{Field: "overrides[c]"},
+				true, sanity.CodeMissingKey, "overrides[c]: is required",
+			},
+		},
+		{
+			name: "MissingKeyError keeps type under prefix",
+			function: func() interface{} {
+				err := sanity.WithPrefix("cfg", sanity.MapHasKeys("m", map[string]int{}, "k"))
+				var mk sanity.MissingKeyError
+				return []interface{}{errors.As(err, &mk), mk.Field}
+			},
+			expected: []interface{}{true, "cfg.m[k]"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
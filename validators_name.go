@@ -0,0 +1,136 @@
+package sanity
+
+import (
+	"strings"
+	"time"
+)
+
+// The *N variants below take a Name that is resolved only when the check fails.
+
+func NotNilPtrN[T any](name Name, p *T) error {
+	if p == nil {
+		return NotNilError{Field: name.String()}
+	}
+	return nil
+}
+
+func NonZeroN[T comparable](name Name, v T) error {
+	var zero T
+	if v == zero {
+		return NonZeroError{Field: name.String()}
+	}
+	return nil
+}
+
+func NonEmptyN(name Name, s string) error {
+	if s == "" {
+		return NonEmptyError{Field: name.String()}
+	}
+	return nil
+}
+
+func NonBlankN(name Name, s string) error {
+	if len(strings.TrimSpace(s)) == 0 {
+		return NonEmptyError{Field: name.String()}
+	}
+	return nil
+}
+
+func StrLenAtLeastN(name Name, s string, n int) error {
+	if len(s) < n {
+		return LenAtLeastError{Field: name.String(), Want: n, Got: len(s)}
+	}
+	return nil
+}
+
+func SliceLenAtLeastN[T any](name Name, s []T, n int) error {
+	if len(s) < n {
+		return LenAtLeastError{Field: name.String(), Want: n, Got: len(s)}
+	}
+	return nil
+}
+
+func MapLenAtLeastN[K comparable, V any](name Name, m map[K]V, n int) error {
+	if len(m) < n {
+		return LenAtLeastError{Field: name.String(), Want: n, Got: len(m)}
+	}
+	return nil
+}
+
+func StrLenAtMostN(name Name, s string, n int) error {
+	if len(s) > n {
+		return LenAtMostError{Field: name.String(), Want: n, Got: len(s)}
+	}
+	return nil
+}
+
+func StrLenBetweenN(name Name, s string, min, max int) error {
+	if min > max {
+		min, max = max, min
+	}
+	if l := len(s); l < min || l > max {
+		return LenBetweenError{Field: name.String(), Min: min, Max: max, Got: l}
+	}
+	return nil
+}
+
+func SliceLenAtMostN[T any](name Name, s []T, n int) error {
+	if len(s) > n {
+		return LenAtMostError{Field: name.String(), Want: n, Got: len(s)}
+	}
+	return nil
+}
+
+func SliceLenBetweenN[T any](name Name, s []T, min, max int) error {
+	if min > max {
+		min, max = max, min
+	}
+	if l := len(s); l < min || l > max {
+		return LenBetweenError{Field: name.String(), Min: min, Max: max, Got: l}
+	}
+	return nil
+}
+
+func MapLenAtMostN[K comparable, V any](name Name, m map[K]V, n int) error {
+	if len(m) > n {
+		return LenAtMostError{Field: name.String(), Want: n, Got: len(m)}
+	}
+	return nil
+}
+
+func MapLenBetweenN[K comparable, V any](name Name, m map[K]V, min, max int) error {
+	if min > max {
+		min, max = max, min
+	}
+	if l := len(m); l < min || l > max {
+		return LenBetweenError{Field: name.String(), Min: min, Max: max, Got: l}
+	}
+	return nil
+}
+
+func InSetN[T comparable](name Name, v T, set map[T]struct{}) error {
+	if _, ok := set[v]; !ok {
+		return NotInSetError{Field: name.String()}
+	}
+	return nil
+}
+
+func InRangeNumN[T Numeric](name Name, v, min, max T) error {
+	if min > max {
+		min, max = max, min
+	}
+	if v < min || v > max {
+		return OutOfRangeError[T]{Field: name.String(), Min: min, Max: max, Got: v}
+	}
+	return nil
+}
+
+func InRangeDurationN(name Name, v, min, max time.Duration) error {
+	if min > max {
+		min, max = max, min
+	}
+	if v < min || v > max {
+		return OutOfRangeError[time.Duration]{Field: name.String(), Min: min, Max: max, Got: v}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// Network category sentinels (for errors.Is).
+var (
+	ErrInvalidIP       = errors.New("sanity:invalid_ip")
+	ErrInvalidCIDR     = errors.New("sanity:invalid_cidr")
+	ErrInvalidHostname = errors.New("sanity:invalid_hostname")
+	ErrInvalidPort     = errors.New("sanity:invalid_port")
+	ErrInvalidHostPort = errors.New("sanity:invalid_host_port")
+)
+
+// InvalidAddrError indicates a malformed network address. Kind is one of the
+// network sentinels above and is what the error unwraps to.
+type InvalidAddrError struct {
+	Field string
+	Kind  error
+	Got   string
+}
+
+func (e InvalidAddrError) Unwrap() error     { return e.Kind }
+func (e InvalidAddrError) FieldName() string { return e.Field }
+func (e InvalidAddrError) Error() string     { return e.FieldName() + ": " + e.message(redacting()) }
+func (e InvalidAddrError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e InvalidAddrError) Code() string {
+	switch e.Kind {
+	case ErrInvalidIP:
+		return CodeInvalidIP
+	case ErrInvalidCIDR:
+		return CodeInvalidCIDR
+	case ErrInvalidHostname:
+		return CodeInvalidHostname
+	case ErrInvalidPort:
+		return CodeInvalidPort
+	default:
+		return CodeInvalidHostPort
+	}
+}
+
+func (e InvalidAddrError) message(redact bool) string {
+	msg := "must be a valid host:port"
+	switch e.Kind {
+	case ErrInvalidIP:
+		msg = "must be a valid IP address"
+	case ErrInvalidCIDR:
+		msg = "must be a valid CIDR"
+	case ErrInvalidHostname:
+		msg = "must be a valid hostname"
+	case ErrInvalidPort:
+		msg = "must be a port in [1,65535]"
+	}
+	if redact {
+		return msg
+	}
+	return fmt.Sprintf("%s, got %q", msg, e.Got)
+}
+
+// ValidIP checks that s is an IPv4 or IPv6 address.
+func ValidIP(name, s string) error {
+	if net.ParseIP(s) == nil {
+		return InvalidAddrError{Field: name, Kind: ErrInvalidIP, Got: s}
+	}
+	return nil
+}
+
+// ValidCIDR checks that s is an IP prefix such as "10.0.0.0/8".
+func ValidCIDR(name, s string) error {
+	if _, _, err := net.ParseCIDR(s); err != nil {
+		return InvalidAddrError{Field: name, Kind: ErrInvalidCIDR, Got: s}
+	}
+	return nil
+}
+
+// ValidHostname checks s against RFC 1123: at most 253 characters, dot-separated
+// labels of 1-63 letters, digits or hyphens, not starting or ending with a
+// hyphen. A single trailing dot is allowed.
+func ValidHostname(name, s string) error {
+	if !isHostname(s) {
+		return InvalidAddrError{Field: name, Kind: ErrInvalidHostname, Got: s}
+	}
+	return nil
+}
+
+// ValidPort checks that s is a decimal port number in [1,65535].
+func ValidPort(name, s string) error {
+	if !isPort(s) {
+		return InvalidAddrError{Field: name, Kind: ErrInvalidPort, Got: s}
+	}
+	return nil
+}
+
+// ValidHostPort checks that s is "host:port" where host is a hostname, an IP
+// (IPv6 in brackets) or empty (e.g. ":8080" for listen addresses).
+func ValidHostPort(name, s string) error {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil || !isPort(port) || (host != "" && net.ParseIP(host) == nil && !isHostname(host)) {
+		return InvalidAddrError{Field: name, Kind: ErrInvalidHostPort, Got: s}
+	}
+	return nil
+}
+
+func isPort(s string) bool {
+	n, err := strconv.ParseUint(s, 10, 16)
+	return err == nil && n > 0
+}
+
+func isHostname(s string) bool {
+	if n := len(s); n > 0 && s[n-1] == '.' {
+		s = s[:n-1]
+	}
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	label := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.':
+			if label == 0 || s[i-1] == '-' {
+				return false
+			}
+			label = 0
+			continue
+		case c == '-':
+			if label == 0 {
+				return false
+			}
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		default:
+			return false
+		}
+		if label++; label > 63 {
+			return false
+		}
+	}
+	return label > 0 && s[len(s)-1] != '-'
+}
@@ -0,0 +1,112 @@
+package sanity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestNetValidators(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "ValidIP",
+			function: func() interface{} {
+				return []bool{
+					sanity.ValidIP("ip", "10.0.0.1") == nil,
+					sanity.ValidIP("ip", "::1") == nil,
+					errors.Is(sanity.ValidIP("ip", "10.0.0.256"), sanity.ErrInvalidIP),
+					errors.Is(sanity.ValidIP("ip", ""), sanity.ErrInvalidIP),
+				}
+			},
+			expected: []bool{true, true, true, true},
+		},
+		{
+			name: "ValidCIDR",
+			function: func() interface{} {
+				return []bool{
+					sanity.ValidCIDR("net", "10.0.0.0/8") == nil,
+					sanity.ValidCIDR("net", "fd00::/64") == nil,
+					errors.Is(sanity.ValidCIDR("net", "10.0.0.0"), sanity.ErrInvalidCIDR),
+					errors.Is(sanity.ValidCIDR("net", "10.0.0.0/33"), sanity.ErrInvalidCIDR),
+				}
+			},
+			expected: []bool{true, true, true, true},
+		},
+		{
+			name: "ValidHostname",
+			function: func() interface{} {
+				return []bool{
+					sanity.ValidHostname("host", "api.example.com") == nil,
+					sanity.ValidHostname("host", "localhost.") == nil,
+					sanity.ValidHostname("host", "a-b.c1") == nil,
+					errors.Is(sanity.ValidHostname("host", "-a.com"), sanity.ErrInvalidHostname),
+					errors.Is(sanity.ValidHostname("host", "a-.com"), sanity.ErrInvalidHostname),
+					errors.Is(sanity.ValidHostname("host", "a..com"), sanity.ErrInvalidHostname),
+					errors.Is(sanity.ValidHostname("host", "a_b.com"), sanity.ErrInvalidHostname),
+					errors.Is(sanity.ValidHostname("host", strings.Repeat("a", 64)+".com"), sanity.ErrInvalidHostname),
+					errors.Is(sanity.ValidHostname("host", ""), sanity.ErrInvalidHostname),
+				}
+			},
+			expected: []bool{true, true, true, true, true, true, true, true, true},
+		},
+		{
+			name: "ValidPort",
+			function: func() interface{} {
+				return []bool{
+					sanity.ValidPort("port", "1") == nil,
+					sanity.ValidPort("port", "65535") == nil,
+					errors.Is(sanity.ValidPort("port", "0"), sanity.ErrInvalidPort),
+					errors.Is(sanity.ValidPort("port", "65536"), sanity.ErrInvalidPort),
+					errors.Is(sanity.ValidPort("port", "+80"), sanity.ErrInvalidPort),
+					errors.Is(sanity.ValidPort("port", "http"), sanity.ErrInvalidPort),
+				}
+			},
+			expected: []bool{true, true, true, true, true, true},
+		},
+		{
+			name: "ValidHostPort",
+			function: func() interface{} {
+				return []bool{
+					sanity.ValidHostPort("addr", "db.internal:5432") == nil,
+					sanity.ValidHostPort("addr", "[::1]:443") == nil,
+					sanity.ValidHostPort("addr", ":8080") == nil,
+					errors.Is(sanity.ValidHostPort("addr", "db.internal"), sanity.ErrInvalidHostPort),
+					errors.Is(sanity.ValidHostPort("addr", "db_1:5432"), sanity.ErrInvalidHostPort),
+					errors.Is(sanity.ValidHostPort("addr", "db:0"), sanity.ErrInvalidHostPort),
+				}
+			},
+			expected: []bool{true, true, true, true, true, true},
+		},
+		{
+			name: "Typed error: field, code, prefix",
+			function: func() interface{} {
+				err := sanity.WithPrefix("db", sanity.ValidPort("port", "x"))
+				var ae sanity.InvalidAddrError
+				return []interface{}{errors.As(err, &ae), ae.FieldName(), ae.Got, sanity.CodeOf(err), errors.Is(err, sanity.ErrInvalidIP)}
+			},
+			expected: []interface{}{true, "db.port", "x", "INVALID_PORT", false},
+		},
+		{
+			name: "Redacted message omits the value",
+			function: func() interface{} {
+				return sanity.Redacted(sanity.ValidIP("ip", "secret")).Error()
+			},
+			expected: "ip: must be a valid IP address",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
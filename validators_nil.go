@@ -0,0 +1,33 @@
+package sanity
+
+import "reflect"
+
+// NotNilAny returns NotNilError if v is nil or holds a nil pointer, map,
+// slice, func, channel or unsafe.Pointer. A typed nil stored in an interface
+// (an io.Writer holding a nil *bytes.Buffer) compares unequal to nil, so a
+// plain v == nil check lets it through; NotNilAny uses reflection to catch it.
+func NotNilAny(name string, v any) error {
+	if isNilValue(v) {
+		return NotNilError{Field: name}
+	}
+	return nil
+}
+
+// NotNilIface is NotNilAny for a value of interface type I, such as a
+// dependency passed to a constructor:
+//
+//	g.Add(sanity.NotNilIface("logger", logger))
+func NotNilIface[I any](name string, v I) error {
+	return NotNilAny(name, v)
+}
+
+func isNilValue(v any) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
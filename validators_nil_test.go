@@ -0,0 +1,68 @@
+package sanity_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/sessaidi/sanity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotNilAny(t *testing.T) {
+	var (
+		buf     *bytes.Buffer
+		typed   io.Writer = buf
+		nilMap  map[string]int
+		nilFunc func()
+		nilChan chan int
+		nilS    []int
+	)
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Nil values of every nilable kind fail",
+			function: func() interface{} {
+				var failed []bool
+				for _, v := range []any{nil, typed, nilMap, nilFunc, nilChan, nilS, buf} {
+					failed = append(failed, errors.Is(sanity.NotNilAny("v", v), sanity.ErrNotNil))
+				}
+				return failed
+			},
+			expected: []bool{true, true, true, true, true, true, true},
+		},
+		{
+			name: "Non-nil and non-nilable values pass",
+			function: func() interface{} {
+				var passed []bool
+				for _, v := range []any{&bytes.Buffer{}, map[string]int{}, func() {}, make(chan int), []int{}, 0, "", struct{}{}} {
+					passed = append(passed, sanity.NotNilAny("v", v) == nil)
+				}
+				return passed
+			},
+			expected: []bool{true, true, true, true, true, true, true, true},
+		},
+		{
+			name: "NotNilIface catches typed nils",
+			function: func() interface{} {
+				var w io.Writer
+				return []interface{}{
+					fieldOf(sanity.NotNilIface("out", typed)),
+					fieldOf(sanity.NotNilIface("out", w)),
+					sanity.NotNilIface[io.Writer]("out", &bytes.Buffer{}) == nil,
+				}
+			},
+			expected: []interface{}{"out", "out", true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package sanity
+
+import (
+	"cmp"
+	"errors"
+)
+
+// Category sentinels for element checks that have no typed error of their own.
+var (
+	ErrDuplicate = errors.New("sanity:duplicate")
+	ErrNotSorted = errors.New("sanity:not_sorted")
+)
+
+// ElementError reports a failing slice element. Field is the element path
+// ("tags[2]"), Index its position; Err is the cause (ErrNotNil, ErrNonZero,
+// ErrDuplicate, ErrNotSorted, or any error) and is reachable via errors.Is/As.
+type ElementError struct {
+	Field string
+	Index int
+	Err   error
+}
+
+func (e ElementError) Unwrap() error     { return e.Err }
+func (e ElementError) FieldName() string { return e.Field }
+func (e ElementError) Error() string     { return e.FieldName() + ": " + e.message(redacting()) }
+func (e ElementError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e ElementError) message(redact bool) string {
+	switch e.Err {
+	case ErrNotNil:
+		return NotNilError{}.message(redact)
+	case ErrNonZero:
+		return NonZeroError{}.message(redact)
+	case ErrDuplicate:
+		return "duplicate value"
+	case ErrNotSorted:
+		return "out of order"
+	}
+	return FieldPathError{Err: e.Err}.message(redact)
+}
+
+func (e ElementError) Code() string {
+	switch e.Err {
+	case ErrNotNil:
+		return CodeNotNil
+	case ErrNonZero:
+		return CodeNonZero
+	case ErrDuplicate:
+		return CodeDuplicate
+	case ErrNotSorted:
+		return CodeNotSorted
+	}
+	return CodeOf(e.Err)
+}
+
+func elementError(name string, i int, cause error) error {
+	return ElementError{Field: Indexed(name, i).String(), Index: i, Err: cause}
+}
+
+// SliceNoNils reports the first nil element of s.
+func SliceNoNils[T any](name string, s []*T) error {
+	for i, p := range s {
+		if p == nil {
+			return elementError(name, i, ErrNotNil)
+		}
+	}
+	return nil
+}
+
+// SliceAllNonZero reports the first zero element of s.
+func SliceAllNonZero[T comparable](name string, s []T) error {
+	var zero T
+	for i, v := range s {
+		if v == zero {
+			return elementError(name, i, ErrNonZero)
+		}
+	}
+	return nil
+}
+
+// SliceUnique reports the first element equal to an earlier one.
+func SliceUnique[T comparable](name string, s []T) error {
+	if len(s) < 2 {
+		return nil
+	}
+	seen := make(map[T]struct{}, len(s))
+	for i, v := range s {
+		if _, dup := seen[v]; dup {
+			return elementError(name, i, ErrDuplicate)
+		}
+		seen[v] = struct{}{}
+	}
+	return nil
+}
+
+// SliceSorted reports the first element smaller than its predecessor
+// (s must be in non-decreasing order; equal neighbours are allowed).
+func SliceSorted[T cmp.Ordered](name string, s []T) error {
+	for i := 1; i < len(s); i++ {
+		if cmp.Less(s[i], s[i-1]) {
+			return elementError(name, i, ErrNotSorted)
+		}
+	}
+	return nil
+}
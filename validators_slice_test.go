@@ -0,0 +1,93 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestSliceValidators(t *testing.T) {
+	one := 1
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Valid slices pass",
+			function: func() interface{} {
+				return []bool{
+					sanity.SliceNoNils("ps", []*int{&one, &one}) == nil,
+					sanity.SliceAllNonZero("ids", []int{1, 2}) == nil,
+					sanity.SliceUnique("tags", []string{"a", "b"}) == nil,
+					sanity.SliceSorted("ts", []int{1, 1, 2}) == nil,
+					sanity.SliceUnique[int]("tags", nil) == nil,
+				}
+			},
+			expected: []bool{true, true, true, true, true},
+		},
+		{
+			name: "SliceNoNils reports index",
+			function: func() interface{} {
+				err := sanity.SliceNoNils("ps", []*int{&one, nil})
+				return []interface{}{err, errors.Is(err, sanity.ErrNotNil), sanity.CodeOf(err), err.Error()}
+			},
+			expected: []interface{}{
+				sanity.ElementError{Field: "ps[1]", Index: 1, Err: sanity.ErrNotNil},
+				true, sanity.CodeNotNil, "ps[1]: must not be nil",
+			},
+		},
+		{
+			name: "SliceAllNonZero reports first zero",
+			function: func() interface{} {
+				err := sanity.SliceAllNonZero("ids", []int{3, 0, 0})
+				return []interface{}{fieldOf(err), errors.Is(err, sanity.ErrNonZero), sanity.CodeOf(err)}
+			},
+			expected: []interface{}{"ids[1]", true, sanity.CodeNonZero},
+		},
+		{
+			name: "SliceUnique reports the repeat",
+			function: func() interface{} {
+				err := sanity.SliceUnique("tags", []string{"a", "b", "a"})
+				return []interface{}{fieldOf(err), errors.Is(err, sanity.ErrDuplicate), sanity.CodeOf(err), err.Error()}
+			},
+			expected: []interface{}{"tags[2]", true, sanity.CodeDuplicate, "tags[2]: duplicate value"},
+		},
+		{
+			name: "SliceSorted reports first inversion",
+			function: func() interface{} {
+				err := sanity.SliceSorted("ts", []float64{1, 3, 2})
+				var ee sanity.ElementError
+				errors.As(err, &ee)
+				return []interface{}{ee.Index, errors.Is(err, sanity.ErrNotSorted), sanity.CodeOf(err)}
+			},
+			expected: []interface{}{2, true, sanity.CodeNotSorted},
+		},
+		{
+			name: "Prefix keeps ElementError",
+			function: func() interface{} {
+				err := sanity.WithPrefix("req", sanity.SliceUnique("tags", []int{1, 1}))
+				var ee sanity.ElementError
+				return []interface{}{errors.As(err, &ee), ee.Field, ee.Index}
+			},
+			expected: []interface{}{true, "req.tags[1]", 1},
+		},
+		{
+			name: "Arbitrary cause",
+			function: func() interface{} {
+				err := sanity.ElementError{Field: "xs[0]", Err: sanity.NonEmpty("x", "")}
+				return []interface{}{err.Error(), sanity.CodeOf(err)}
+			},
+			expected: []interface{}{"xs[0]: must be non-empty", sanity.CodeNonEmpty},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -101,6 +101,73 @@ func TestValidators(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "LenAtMost long -> ErrLenAtMost",
+			function: func() interface{} {
+				return [4]interface{}{
+					sanity.StrLenAtMost("name", "abc", 2),
+					errors.Is(sanity.SliceLenAtMost("xs", []int{1, 2}, 1), sanity.ErrLenAtMost),
+					errors.Is(sanity.MapLenAtMost("m", map[int]int{1: 1, 2: 2}, 1), sanity.ErrLenAtMost),
+					sanity.StrLenAtMost("name", "ab", 2) == nil,
+				}
+			},
+			expected: [4]interface{}{sanity.LenAtMostError{Field: "name", Want: 2, Got: 3}, true, true, true},
+		},
+		{
+			name: "LenBetween outside -> ErrLenBetween",
+			function: func() interface{} {
+				return [4]interface{}{
+					sanity.StrLenBetween("name", "a", 4, 2),
+					errors.Is(sanity.SliceLenBetween("xs", []int{1, 2, 3}, 1, 2), sanity.ErrLenBetween),
+					errors.Is(sanity.MapLenBetween("m", map[int]int{}, 1, 2), sanity.ErrLenBetween),
+					sanity.SliceLenBetween("xs", []int{1, 2}, 2, 2) == nil,
+				}
+			},
+			expected: [4]interface{}{sanity.LenBetweenError{Field: "name", Min: 2, Max: 4, Got: 1}, true, true, true},
+		},
+		{
+			name: "OneOf hit and miss",
+			function: func() interface{} {
+				err := sanity.OneOf("mode", "x", "auto", "manual")
+				return [4]interface{}{
+					sanity.OneOf("mode", "auto", "auto", "manual") == nil,
+					errors.Is(err, sanity.ErrNotInSet),
+					err.Error(),
+					sanity.Redacted(sanity.OneOf("level", 7, 1, 2, 3)).Error(),
+				}
+			},
+			expected: [4]interface{}{true, true, "mode: must be one of [auto, manual]", "level: must be one of [1, 2, 3]"},
+		},
+		{
+			name: "NotInSetError stays comparable",
+			function: func() interface{} {
+				err := sanity.OneOf("mode", "x", "auto", "manual")
+				return [3]bool{
+					err == error(sanity.NotInSetError{Field: "mode"}),
+					sanity.InSet("mode", "x", map[string]struct{}{}) == error(sanity.NotInSetError{Field: "mode"}),
+					err == err,
+				}
+			},
+			expected: [3]bool{false, true, true},
+		},
+		{
+			name: "InSetVerbose lists sorted values via SetError",
+			function: func() interface{} {
+				set := map[string]struct{}{"manual": {}, "auto": {}}
+				err := sanity.InSetVerbose("mode", "x", set)
+				var se sanity.SetError
+				ok := errors.As(err, &se)
+				return [4]interface{}{sanity.InSetVerbose("mode", "auto", set) == nil, ok, se.FieldName(), len(se.AllowedValues())}
+			},
+			expected: [4]interface{}{true, true, "mode", 2},
+		},
+		{
+			name: "InSetVerbose message",
+			function: func() interface{} {
+				return sanity.InSetVerbose("n", 4, map[int]struct{}{3: {}, 1: {}, 2: {}}).Error()
+			},
+			expected: "n: must be one of [1, 2, 3]",
+		},
 		{
 			name: "InSet miss -> ErrNotInSet",
 			function: func() interface{} {
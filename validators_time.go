@@ -0,0 +1,144 @@
+package sanity
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTimeRange is the category sentinel of TimeRangeError.
+var ErrTimeRange = errors.New("sanity:time_range")
+
+// Constraint kinds reported in TimeRangeError.Kind.
+const (
+	TimeKindRange  = "range"  // Min <= Got <= Max
+	TimeKindBefore = "before" // Got < Max
+	TimeKindAfter  = "after"  // Got > Min
+	TimeKindOrder  = "order"  // start (Got) < end (Max)
+)
+
+// TimeRangeError indicates an absolute time outside its allowed window.
+// Bounds that do not apply to Kind are zero. Times render as RFC 3339.
+type TimeRangeError struct {
+	Field    string
+	Kind     string
+	Min, Max time.Time
+	Got      time.Time
+}
+
+func (e TimeRangeError) Unwrap() error     { return ErrTimeRange }
+func (e TimeRangeError) FieldName() string { return e.Field }
+func (e TimeRangeError) Code() string      { return CodeTimeRange }
+func (e TimeRangeError) Error() string     { return e.FieldName() + ": " + e.message(redacting()) }
+func (e TimeRangeError) gotValue() any     { return e.Got }
+func (e TimeRangeError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e TimeRangeError) message(redact bool) string {
+	var msg string
+	switch e.Kind {
+	case TimeKindBefore:
+		msg = "must be before " + fmtTime(e.Max)
+	case TimeKindAfter:
+		msg = "must be after " + fmtTime(e.Min)
+	case TimeKindOrder:
+		if redact {
+			return "start must be before end"
+		}
+		return "start must be before end (start " + fmtTime(e.Got) + ", end " + fmtTime(e.Max) + ")"
+	default:
+		msg = "must be in [" + fmtTime(e.Min) + "," + fmtTime(e.Max) + "]"
+	}
+	if redact {
+		return msg
+	}
+	return msg + ", got " + fmtTime(e.Got)
+}
+
+func fmtTime(t time.Time) string { return t.Format(time.RFC3339Nano) }
+
+// InRangeTime checks min <= t <= max (bounds are swapped if inverted).
+func InRangeTime(name string, t, min, max time.Time) error {
+	if min.After(max) {
+		min, max = max, min
+	}
+	if t.Before(min) || t.After(max) {
+		return TimeRangeError{Field: name, Kind: TimeKindRange, Min: min, Max: max, Got: t}
+	}
+	return nil
+}
+
+// TimeNotZero checks that t is set; it reports NonZeroError like NonZero.
+// Use it instead of NonZero, which compares location and monotonic readings.
+func TimeNotZero(name string, t time.Time) error {
+	if t.IsZero() {
+		return NonZeroError{Field: name}
+	}
+	return nil
+}
+
+// TimeBefore checks t < limit.
+func TimeBefore(name string, t, limit time.Time) error {
+	if !t.Before(limit) {
+		return TimeRangeError{Field: name, Kind: TimeKindBefore, Max: limit, Got: t}
+	}
+	return nil
+}
+
+// TimeAfter checks t > limit.
+func TimeAfter(name string, t, limit time.Time) error {
+	if !t.After(limit) {
+		return TimeRangeError{Field: name, Kind: TimeKindAfter, Min: limit, Got: t}
+	}
+	return nil
+}
+
+// StartBeforeEnd checks that the window named name starts strictly before it ends.
+func StartBeforeEnd(name string, start, end time.Time) error {
+	if !start.Before(end) {
+		return TimeRangeError{Field: name, Kind: TimeKindOrder, Max: end, Got: start}
+	}
+	return nil
+}
+
+// TimeOption configures the clock-relative time validators.
+type TimeOption func(*timeConfig)
+
+type timeConfig struct {
+	now func() time.Time
+}
+
+// WithNow replaces time.Now, e.g. with a fixed clock in tests.
+func WithNow(now func() time.Time) TimeOption {
+	return func(c *timeConfig) { c.now = now }
+}
+
+func nowOf(opts []TimeOption) time.Time {
+	c := timeConfig{now: time.Now}
+	for _, o := range opts {
+		o(&c)
+	}
+	return c.now()
+}
+
+// TimeInFuture checks t > now.
+func TimeInFuture(name string, t time.Time, opts ...TimeOption) error {
+	return TimeAfter(name, t, nowOf(opts))
+}
+
+// TimeInPast checks t < now.
+func TimeInPast(name string, t time.Time, opts ...TimeOption) error {
+	return TimeBefore(name, t, nowOf(opts))
+}
+
+// TimeWithin checks that t lies within d of now in either direction (freshness
+// of a timestamp, or an expiry that is not too far out). A negative d is
+// treated as its absolute value.
+func TimeWithin(name string, t time.Time, d time.Duration, opts ...TimeOption) error {
+	if d < 0 {
+		d = -d
+	}
+	now := nowOf(opts)
+	return InRangeTime(name, t, now.Add(-d), now.Add(d))
+}
@@ -0,0 +1,109 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+var (
+	t0 = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 = t0.Add(time.Hour)
+	t2 = t0.Add(2 * time.Hour)
+)
+
+func TestTimeValidators(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Passing values",
+			function: func() interface{} {
+				return []bool{
+					sanity.InRangeTime("at", t1, t0, t2) == nil,
+					sanity.InRangeTime("at", t0, t2, t0) == nil,
+					sanity.TimeNotZero("at", t0) == nil,
+					sanity.TimeBefore("at", t0, t1) == nil,
+					sanity.TimeAfter("at", t1, t0) == nil,
+					sanity.StartBeforeEnd("window", t0, t1) == nil,
+				}
+			},
+			expected: []bool{true, true, true, true, true, true},
+		},
+		{
+			name: "Failures match ErrTimeRange",
+			function: func() interface{} {
+				return []bool{
+					errors.Is(sanity.InRangeTime("at", t2.Add(time.Second), t0, t2), sanity.ErrTimeRange),
+					errors.Is(sanity.TimeBefore("at", t1, t1), sanity.ErrTimeRange),
+					errors.Is(sanity.TimeAfter("at", t0, t1), sanity.ErrTimeRange),
+					errors.Is(sanity.StartBeforeEnd("window", t1, t1), sanity.ErrTimeRange),
+					errors.Is(sanity.TimeNotZero("at", time.Time{}), sanity.ErrNonZero),
+				}
+			},
+			expected: []bool{true, true, true, true, true},
+		},
+		{
+			name: "Error details",
+			function: func() interface{} {
+				err := sanity.TimeBefore("expires", t2, t1)
+				var te sanity.TimeRangeError
+				errors.As(err, &te)
+				return []interface{}{te.Kind, te.Max, te.Got, sanity.CodeOf(err)}
+			},
+			expected: []interface{}{sanity.TimeKindBefore, t1, t2, sanity.CodeTimeRange},
+		},
+		{
+			name: "Messages",
+			function: func() interface{} {
+				inRange := sanity.InRangeTime("at", t2, t0, t1).Error()
+				order := sanity.StartBeforeEnd("window", t1, t0).Error()
+				return []interface{}{
+					inRange == "at: must be in [2025-01-01T00:00:00Z,2025-01-01T01:00:00Z], got 2025-01-01T02:00:00Z" || sanity.RedactBuild,
+					order == "window: start must be before end (start 2025-01-01T01:00:00Z, end 2025-01-01T00:00:00Z)" || sanity.RedactBuild,
+					sanity.Redacted(sanity.TimeAfter("at", t0, t1)).Error(),
+					sanity.Redacted(sanity.StartBeforeEnd("window", t1, t0)).Error(),
+				}
+			},
+			expected: []interface{}{true, true, "at: must be after 2025-01-01T01:00:00Z", "window: start must be before end"},
+		},
+		{
+			name: "Clock-relative checks use the injected clock",
+			function: func() interface{} {
+				now := sanity.WithNow(func() time.Time { return t1 })
+				return []bool{
+					sanity.TimeInFuture("expires", t2, now) == nil,
+					errors.Is(sanity.TimeInFuture("expires", t0, now), sanity.ErrTimeRange),
+					sanity.TimeInPast("issued", t0, now) == nil,
+					errors.Is(sanity.TimeInPast("issued", t1, now), sanity.ErrTimeRange),
+					sanity.TimeWithin("seen", t0, time.Hour, now) == nil,
+					sanity.TimeWithin("seen", t2, -time.Hour, now) == nil,
+					errors.Is(sanity.TimeWithin("seen", t0, time.Minute, now), sanity.ErrTimeRange),
+				}
+			},
+			expected: []bool{true, true, true, true, true, true, true},
+		},
+		{
+			name: "Default clock is time.Now",
+			function: func() interface{} {
+				return []bool{
+					sanity.TimeInPast("t", t0) == nil,
+					sanity.TimeInFuture("t", time.Now().Add(time.Hour)) == nil,
+				}
+			},
+			expected: []bool{true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
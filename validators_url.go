@@ -0,0 +1,74 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidURL is the category sentinel of InvalidURLError.
+var ErrInvalidURL = errors.New("sanity:invalid_url")
+
+// InvalidURLError indicates a value that is not an absolute URL, or whose
+// scheme is not one of AllowedSchemes. The schemes are held behind a pointer
+// so the type stays comparable.
+type InvalidURLError struct {
+	Field   string
+	Got     string
+	schemes *[]string
+}
+
+func invalidURL(name, got string, schemes []string) InvalidURLError {
+	return InvalidURLError{Field: name, Got: got, schemes: &schemes}
+}
+
+// AllowedSchemes lists the schemes ValidURL accepted; empty means any.
+func (e InvalidURLError) AllowedSchemes() []string {
+	if e.schemes == nil {
+		return nil
+	}
+	return *e.schemes
+}
+
+func (e InvalidURLError) Unwrap() error     { return ErrInvalidURL }
+func (e InvalidURLError) FieldName() string { return e.Field }
+func (e InvalidURLError) Code() string      { return CodeInvalidURL }
+func (e InvalidURLError) Error() string     { return e.FieldName() + ": " + e.message(redacting()) }
+func (e InvalidURLError) withFieldName(name string) error {
+	e.Field = name
+	return e
+}
+
+func (e InvalidURLError) message(redact bool) string {
+	msg := "must be an absolute URL"
+	if schemes := e.AllowedSchemes(); len(schemes) > 0 {
+		msg += " with scheme " + strings.Join(schemes, "|")
+	}
+	if redact {
+		return msg
+	}
+	return fmt.Sprintf("%s, got %q", msg, e.Got)
+}
+
+// ValidURL checks that s parses as an absolute URL with a host and, when
+// schemes are given, that its scheme is one of them (case-insensitive).
+func ValidURL(name, s string, schemes ...string) error {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" || !schemeAllowed(u.Scheme, schemes) {
+		return invalidURL(name, s, schemes)
+	}
+	return nil
+}
+
+func schemeAllowed(scheme string, schemes []string) bool {
+	if len(schemes) == 0 {
+		return true
+	}
+	for _, s := range schemes {
+		if strings.EqualFold(s, scheme) {
+			return true
+		}
+	}
+	return false
+}
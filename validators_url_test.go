@@ -0,0 +1,83 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestValidURL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "Absolute URLs pass",
+			function: func() interface{} {
+				return []bool{
+					sanity.ValidURL("u", "https://api.example.com/v1?x=1") == nil,
+					sanity.ValidURL("u", "postgres://user@db:5432/app") == nil,
+				}
+			},
+			expected: []bool{true, true},
+		},
+		{
+			name: "Relative, host-less and malformed URLs fail",
+			function: func() interface{} {
+				return []bool{
+					errors.Is(sanity.ValidURL("u", "/v1/users"), sanity.ErrInvalidURL),
+					errors.Is(sanity.ValidURL("u", "example.com"), sanity.ErrInvalidURL),
+					errors.Is(sanity.ValidURL("u", "mailto:a@b.c"), sanity.ErrInvalidURL),
+					errors.Is(sanity.ValidURL("u", "http://[::1"), sanity.ErrInvalidURL),
+					errors.Is(sanity.ValidURL("u", ""), sanity.ErrInvalidURL),
+				}
+			},
+			expected: []bool{true, true, true, true, true},
+		},
+		{
+			name: "Scheme allow-list",
+			function: func() interface{} {
+				return []bool{
+					sanity.ValidURL("u", "HTTPS://example.com", "https") == nil,
+					sanity.ValidURL("u", "wss://example.com", "https", "wss") == nil,
+					errors.Is(sanity.ValidURL("u", "http://example.com", "https"), sanity.ErrInvalidURL),
+				}
+			},
+			expected: []bool{true, true, true},
+		},
+		{
+			name: "Message, field and code",
+			function: func() interface{} {
+				err := sanity.ValidURL("webhook", "http://example.com", "https")
+				var ue sanity.InvalidURLError
+				return []interface{}{
+					errors.As(err, &ue), ue.FieldName(), sanity.CodeOf(err),
+					sanity.Redacted(err).Error(),
+				}
+			},
+			expected: []interface{}{true, "webhook", "INVALID_URL", "webhook: must be an absolute URL with scheme https"},
+		},
+		{
+			name: "InvalidURLError stays comparable",
+			function: func() interface{} {
+				err := sanity.ValidURL("webhook", "http://example.com", "https", "wss")
+				var ue sanity.InvalidURLError
+				errors.As(err, &ue)
+				copied := ue
+				return []interface{}{err == error(copied), errors.Is(err, copied), ue.AllowedSchemes()}
+			},
+			expected: []interface{}{true, true, []string{"https", "wss"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.function()
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
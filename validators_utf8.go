@@ -0,0 +1,43 @@
+package sanity
+
+import "unicode/utf8"
+
+// ValidUTF8 checks that s is valid UTF-8; failures are reported as a
+// FormatError with Format FormatUTF8.
+func ValidUTF8(name, s string) error {
+	if !utf8.ValidString(s) {
+		return FormatError{Field: name, Format: FormatUTF8, Got: s}
+	}
+	return nil
+}
+
+// The RuneLen* validators count characters (runes) rather than bytes, which is
+// what users mean by the length of a display name or comment. Errors reuse the
+// Len* types with Want/Got in runes; invalid bytes count as one rune each.
+
+func RuneLenAtLeast(name, s string, n int) error {
+	if got := utf8.RuneCountInString(s); got < n {
+		return LenAtLeastError{Field: name, Want: n, Got: got}
+	}
+	return nil
+}
+
+func RuneLenAtMost(name, s string, n int) error {
+	if len(s) <= n { // a rune is at least one byte
+		return nil
+	}
+	if got := utf8.RuneCountInString(s); got > n {
+		return LenAtMostError{Field: name, Want: n, Got: got}
+	}
+	return nil
+}
+
+func RuneLenBetween(name, s string, min, max int) error {
+	if min > max {
+		min, max = max, min
+	}
+	if got := utf8.RuneCountInString(s); got < min || got > max {
+		return LenBetweenError{Field: name, Min: min, Max: max, Got: got}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package sanity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sessaidi/sanity"
+)
+
+func TestUTF8Validators(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "ValidUTF8",
+			function: func() interface{} {
+				err := sanity.ValidUTF8("bio", "a\xffb")
+				var fe sanity.FormatError
+				return []interface{}{
+					sanity.ValidUTF8("bio", "héllo") == nil,
+					errors.As(err, &fe), fe.Format, sanity.CodeOf(err),
+				}
+			},
+			expected: []interface{}{true, true, sanity.FormatUTF8, sanity.CodeInvalidFormat},
+		},
+		{
+			name: "RuneLenAtLeast counts runes, not bytes",
+			function: func() interface{} {
+				return []bool{
+					sanity.StrLenAtLeast("name", "日本", 3) == nil,
+					errors.Is(sanity.RuneLenAtLeast("name", "日本", 3), sanity.ErrLenAtLeast),
+					sanity.RuneLenAtLeast("name", "日本語", 3) == nil,
+				}
+			},
+			expected: []bool{true, true, true},
+		},
+		{
+			name: "RuneLenAtMost",
+			function: func() interface{} {
+				err := sanity.RuneLenAtMost("name", "héllo", 4)
+				var le sanity.LenAtMostError
+				return []interface{}{
+					sanity.RuneLenAtMost("name", "héllo", 5) == nil,
+					errors.As(err, &le), le.Want, le.Got, sanity.CodeOf(err),
+				}
+			},
+			expected: []interface{}{true, true, 4, 5, sanity.CodeLenAtMost},
+		},
+		{
+			name: "RuneLenBetween",
+			function: func() interface{} {
+				err := sanity.RuneLenBetween("name", "ab", 8, 3)
+				var re sanity.RangeError
+				errors.As(err, &re)
+				min, max := re.Bounds()
+				return []interface{}{
+					sanity.RuneLenBetween("name", "äöü", 3, 8) == nil,
+					errors.Is(err, sanity.ErrLenBetween), min, max, re.Value(),
+				}
+			},
+			expected: []interface{}{true, true, 3, 8, 2},
+		},
+		{
+			name: "Messages",
+			function: func() interface{} {
+				atMost := sanity.RuneLenAtMost("name", "abc", 2).Error()
+				between := sanity.RuneLenBetween("name", "a", 2, 4).Error()
+				return []interface{}{
+					atMost == "name: len must be <= 2 (got 3)" || sanity.RedactBuild,
+					between == "name: len must be in [2,4] (got 1)" || sanity.RedactBuild,
+					sanity.Redacted(sanity.RuneLenAtMost("name", "abc", 2)).Error(),
+				}
+			},
+			expected: []interface{}{true, true, "name: len must be <= 2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.function())
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package sanity
+
+import "reflect"
+
+// IsZero reports whether v is nil or the zero value of its dynamic type. Unlike
+// SetIfZero it works for non-comparable types (structs holding slices, maps).
+func IsZero(v any) bool {
+	return v == nil || reflect.ValueOf(v).IsZero()
+}
+
+// IsDeepZero is like IsZero but also treats empty (non-nil) slices and maps as
+// zero, and looks through pointers, interfaces, arrays and struct fields: a
+// struct is deep-zero when every field is. Pointer cycles are followed once.
+func IsDeepZero(v any) bool {
+	if v == nil {
+		return true
+	}
+	return deepZero(reflect.ValueOf(v), nil)
+}
+
+// SetIfDeepZero sets *p to def if IsDeepZero(*p).
+func SetIfDeepZero[T any](p *T, def T) {
+	if deepZero(reflect.ValueOf(p).Elem(), nil) {
+		*p = def
+	}
+}
+
+func deepZero(rv reflect.Value, seen map[uintptr]struct{}) bool {
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	case reflect.Interface:
+		return rv.IsNil() || deepZero(rv.Elem(), seen)
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return true
+		}
+		addr := rv.Pointer()
+		if _, ok := seen[addr]; ok {
+			return true
+		}
+		if seen == nil {
+			seen = make(map[uintptr]struct{})
+		}
+		seen[addr] = struct{}{}
+		return deepZero(rv.Elem(), seen)
+	case reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if !deepZero(rv.Index(i), seen) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if !deepZero(rv.Field(i), seen) {
+				return false
+			}
+		}
+		return true
+	default:
+		return rv.IsZero()
+	}
+}
@@ -0,0 +1,143 @@
+package sanity_test
+
+import (
+	"testing"
+
+	"github.com/sessaidi/sanity"
+	"github.com/stretchr/testify/assert"
+)
+
+type zeroInner struct {
+	Tags []string
+}
+
+type zeroCfg struct {
+	Name  string
+	Inner zeroInner
+	Attrs map[string]string
+	Next  *zeroCfg
+}
+
+func TestIsZero(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fn       func() interface{}
+		expected interface{}
+	}{
+		{name: "nil", fn: func() interface{} { return sanity.IsZero(nil) }, expected: true},
+		{name: "zero int", fn: func() interface{} { return sanity.IsZero(0) }, expected: true},
+		{name: "non-zero string", fn: func() interface{} { return sanity.IsZero("x") }, expected: false},
+		{name: "zero struct with slice", fn: func() interface{} { return sanity.IsZero(zeroCfg{}) }, expected: true},
+		{
+			name:     "empty slice is not zero",
+			fn:       func() interface{} { return sanity.IsZero(zeroCfg{Inner: zeroInner{Tags: []string{}}}) },
+			expected: false,
+		},
+		{name: "nil pointer", fn: func() interface{} { return sanity.IsZero((*zeroCfg)(nil)) }, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.fn())
+		})
+	}
+}
+
+func TestIsDeepZero(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fn       func() interface{}
+		expected interface{}
+	}{
+		{name: "nil", fn: func() interface{} { return sanity.IsDeepZero(nil) }, expected: true},
+		{
+			name: "empty slice and map are zero",
+			fn: func() interface{} {
+				return sanity.IsDeepZero(zeroCfg{Inner: zeroInner{Tags: []string{}}, Attrs: map[string]string{}})
+			},
+			expected: true,
+		},
+		{
+			name:     "pointer to zero struct",
+			fn:       func() interface{} { return sanity.IsDeepZero(&zeroCfg{Next: &zeroCfg{}}) },
+			expected: true,
+		},
+		{
+			name:     "nested value",
+			fn:       func() interface{} { return sanity.IsDeepZero(zeroCfg{Next: &zeroCfg{Name: "x"}}) },
+			expected: false,
+		},
+		{
+			name:     "non-empty slice",
+			fn:       func() interface{} { return sanity.IsDeepZero([]int{0}) },
+			expected: false,
+		},
+		{
+			name:     "array of zeros",
+			fn:       func() interface{} { return sanity.IsDeepZero([2]zeroInner{{Tags: []string{}}}) },
+			expected: true,
+		},
+		{
+			name: "pointer cycle",
+			fn: func() interface{} {
+				c := &zeroCfg{}
+				c.Next = c
+				return sanity.IsDeepZero(c)
+			},
+			expected: true,
+		},
+		{
+			name:     "interface holding empty map",
+			fn:       func() interface{} { return sanity.IsDeepZero([]any{map[string]int{}}[0]) },
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.fn())
+		})
+	}
+}
+
+func TestSetIfDeepZero(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fn       func() interface{}
+		expected interface{}
+	}{
+		{
+			name: "deep-zero struct replaced",
+			fn: func() interface{} {
+				v := zeroInner{Tags: []string{}}
+				sanity.SetIfDeepZero(&v, zeroInner{Tags: []string{"a"}})
+				return v
+			},
+			expected: zeroInner{Tags: []string{"a"}},
+		},
+		{
+			name: "populated struct kept",
+			fn: func() interface{} {
+				v := zeroInner{Tags: []string{"b"}}
+				sanity.SetIfDeepZero(&v, zeroInner{Tags: []string{"a"}})
+				return v
+			},
+			expected: zeroInner{Tags: []string{"b"}},
+		},
+		{
+			name: "nil interface replaced",
+			fn: func() interface{} {
+				var v any
+				sanity.SetIfDeepZero(&v, any(1))
+				return v
+			},
+			expected: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.fn())
+		})
+	}
+}